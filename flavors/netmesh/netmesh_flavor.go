@@ -20,6 +20,7 @@ import (
 	"github.com/ligato/cn-infra/core"
 	"github.com/ligato/cn-infra/flavors/local"
 	"github.com/ligato/cn-infra/flavors/rpc"
+	"github.com/ligato/networkservicemesh/plugins/admission"
 	"github.com/ligato/networkservicemesh/plugins/crd"
 	"github.com/ligato/networkservicemesh/plugins/netmesh"
 )
@@ -33,6 +34,21 @@ const (
 
 	// KubeConfigUsage explains the purpose of 'kube-config' flag.
 	KubeConfigUsage = "Path to the kubeconfig file to use for the client connection to K8s cluster"
+
+	// CRDConfigDefault is the default location of the configuration of the
+	// CRD plugin.
+	CRDConfigDefault = "netmeshcrd.conf"
+
+	// CRDConfigUsage explains the purpose of 'netmeshcrd-config' flag.
+	CRDConfigUsage = "Path to the configuration file of the NSM CRD controller"
+
+	// AdmissionConfigDefault is the default location of the configuration of
+	// the admission webhook.
+	AdmissionConfigDefault = "netmeshadmission.conf"
+
+	// AdmissionConfigUsage explains the purpose of 'netmeshadmission-config'
+	// flag.
+	AdmissionConfigUsage = "Path to the configuration file of the NSM admission webhook"
 )
 
 // NewAgent returns a new instance of the Agent with plugins.
@@ -55,10 +71,15 @@ type FlavorNetmesh struct {
 	*local.FlavorLocal
 	// RPC flavor for REST-based management.
 	*rpc.FlavorRPC
+	// CRD plugin manages the NSM custom resources, it is listed first as
+	// the other plugins query its caches.
+	CRD netmeshplugincrd.Plugin
+	// Admission plugin serves the admission webhook of the NSM custom
+	// resources, it validates NetworkServices against the CRD caches.
+	Admission netmeshpluginadmission.Plugin
 	// Kubernetes State Reflector plugin works as a reflector for policies, pods
 	// and namespaces.
 	Netmesh netmesh.Plugin
-	CRD     netmeshplugincrd.Plugin
 
 	injected bool
 }
@@ -84,8 +105,12 @@ func (f *FlavorNetmesh) Inject() (allReadyInjected bool) {
 	// Reuse ForPlugin to define configuration file for 3rd party library (k8s client).
 	f.Netmesh.Deps.KubeConfig = config.ForPlugin("kube", KubeConfigAdmin, KubeConfigUsage)
 	f.Netmesh.StatusMonitor = &f.StatusCheck // StatusCheck included in local.FlavorLocal
-	f.CRD.Deps.PluginInfraDeps = *f.FlavorLocal.InfraDeps("netmeshcrd")
+	f.Netmesh.Deps.CRD = &f.CRD
+	f.Netmesh.Deps.GRPC = &f.FlavorRPC.GRPC
+	f.CRD.Deps.PluginInfraDeps = *f.FlavorLocal.InfraDeps("netmeshcrd", local.WithConf(CRDConfigDefault, CRDConfigUsage))
 	f.CRD.Deps.KubeConfig = config.ForPlugin("kube", KubeConfigAdmin, KubeConfigUsage)
+	f.Admission.Deps.PluginInfraDeps = *f.FlavorLocal.InfraDeps("netmeshadmission", local.WithConf(AdmissionConfigDefault, AdmissionConfigUsage))
+	f.Admission.Deps.CRD = &f.CRD
 
 	return true
 }
@@ -0,0 +1,358 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmesh
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Names of the mechanisms, as written in the type of mechanisms and listed by
+// endpoints
+const (
+	MechanismNameKernelInterface = "kernel-interface"
+	MechanismNameMemif           = "memif"
+	MechanismNameVhostUser       = "vhost-user"
+	MechanismNameSriovVF         = "sriov-vf"
+	MechanismNameVXLAN           = "vxlan"
+	MechanismNameGRE             = "gre"
+	MechanismNameMPLSoGRE        = "mpls-over-gre"
+	MechanismNameSRv6            = "srv6"
+)
+
+// localMechanismNames maps the local mechanisms to their names,
+// LOCAL_MECHANISM_UNSPECIFIED has none
+var localMechanismNames = map[LocalMechanismType]string{
+	LocalMechanismType_KERNEL_INTERFACE: MechanismNameKernelInterface,
+	LocalMechanismType_MEMIF:            MechanismNameMemif,
+	LocalMechanismType_VHOST_USER:       MechanismNameVhostUser,
+	LocalMechanismType_SRIOV_VF:         MechanismNameSriovVF,
+}
+
+// remoteMechanismNames maps the remote mechanisms to their names,
+// REMOTE_MECHANISM_UNSPECIFIED has none
+var remoteMechanismNames = map[RemoteMechanismType]string{
+	RemoteMechanismType_VXLAN:         MechanismNameVXLAN,
+	RemoteMechanismType_GRE:           MechanismNameGRE,
+	RemoteMechanismType_MPLS_OVER_GRE: MechanismNameMPLSoGRE,
+	RemoteMechanismType_SRV6:          MechanismNameSRv6,
+}
+
+// Bounds of the parameters of mechanisms
+const (
+	// MaxInterfaceNameLength is the longest name of a Linux interface
+	MaxInterfaceNameLength = 15
+	// MaxVNI is the highest VXLAN network identifier
+	MaxVNI = 1<<24 - 1
+	// MinMPLSLabel is the lowest MPLS label which is not reserved
+	MinMPLSLabel = 16
+	// MaxMPLSLabel is the highest MPLS label
+	MaxMPLSLabel = 1<<20 - 1
+	// MaxPort is the highest UDP port
+	MaxPort = 1<<16 - 1
+)
+
+// pciAddressRegexp matches PCI addresses in the domain:bus:device.function
+// format
+var pciAddressRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-1][0-9a-fA-F]\.[0-7]$`)
+
+// LocalMechanismNames returns the names of all the local mechanisms, in the
+// order of the enumeration.
+func LocalMechanismNames() []string {
+	names := make([]string, 0, len(localMechanismNames))
+	for i := int32(1); i < int32(len(LocalMechanismType_name)); i++ {
+		names = append(names, localMechanismNames[LocalMechanismType(i)])
+	}
+	return names
+}
+
+// RemoteMechanismNames returns the names of all the remote mechanisms, in the
+// order of the enumeration.
+func RemoteMechanismNames() []string {
+	names := make([]string, 0, len(remoteMechanismNames))
+	for i := int32(1); i < int32(len(RemoteMechanismType_name)); i++ {
+		names = append(names, remoteMechanismNames[RemoteMechanismType(i)])
+	}
+	return names
+}
+
+// Name returns the name of the local mechanism, or an empty string for
+// LOCAL_MECHANISM_UNSPECIFIED.
+func (x LocalMechanismType) Name() string {
+	return localMechanismNames[x]
+}
+
+// Name returns the name of the remote mechanism, or an empty string for
+// REMOTE_MECHANISM_UNSPECIFIED.
+func (x RemoteMechanismType) Name() string {
+	return remoteMechanismNames[x]
+}
+
+// ParseLocalMechanismType returns the local mechanism with the given name.
+// The empty name stands for LOCAL_MECHANISM_UNSPECIFIED.
+func ParseLocalMechanismType(name string) (LocalMechanismType, error) {
+	if name == "" {
+		return LocalMechanismType_LOCAL_MECHANISM_UNSPECIFIED, nil
+	}
+	for mechanism, mechanismName := range localMechanismNames {
+		if name == mechanismName {
+			return mechanism, nil
+		}
+	}
+	return LocalMechanismType_LOCAL_MECHANISM_UNSPECIFIED, fmt.Errorf("unknown local mechanism %q, expected one of %s",
+		name, strings.Join(LocalMechanismNames(), ", "))
+}
+
+// ParseRemoteMechanismType returns the remote mechanism with the given name.
+// The empty name stands for REMOTE_MECHANISM_UNSPECIFIED.
+func ParseRemoteMechanismType(name string) (RemoteMechanismType, error) {
+	if name == "" {
+		return RemoteMechanismType_REMOTE_MECHANISM_UNSPECIFIED, nil
+	}
+	for mechanism, mechanismName := range remoteMechanismNames {
+		if name == mechanismName {
+			return mechanism, nil
+		}
+	}
+	return RemoteMechanismType_REMOTE_MECHANISM_UNSPECIFIED, fmt.Errorf("unknown remote mechanism %q, expected one of %s",
+		name, strings.Join(RemoteMechanismNames(), ", "))
+}
+
+// MechanismType returns the type of the local mechanism.
+func (m *LocalMechanism) MechanismType() (LocalMechanismType, error) {
+	return ParseLocalMechanismType(m.Type)
+}
+
+// MechanismType returns the type of the remote mechanism.
+func (m *RemoteMechanism) MechanismType() (RemoteMechanismType, error) {
+	return ParseRemoteMechanismType(m.Type)
+}
+
+// Validate checks the type of the local mechanism and its parameters. Only
+// the parameters of its type may be set, parameters left empty are filled in
+// by the dataplane.
+func (m *LocalMechanism) Validate() error {
+	mechanism, err := m.MechanismType()
+	if err != nil {
+		return err
+	}
+	if mechanism == LocalMechanismType_LOCAL_MECHANISM_UNSPECIFIED {
+		return fmt.Errorf("mechanism type is required")
+	}
+	set := []bool{
+		LocalMechanismType_KERNEL_INTERFACE: m.KernelInterface != nil,
+		LocalMechanismType_MEMIF:            m.Memif != nil,
+		LocalMechanismType_VHOST_USER:       m.VhostUser != nil,
+		LocalMechanismType_SRIOV_VF:         m.SriovVf != nil,
+	}
+	for other, isSet := range set {
+		if isSet && LocalMechanismType(other) != mechanism {
+			return fmt.Errorf("%s parameters are not allowed for mechanism %s", LocalMechanismType(other).Name(), m.Type)
+		}
+	}
+
+	switch mechanism {
+	case LocalMechanismType_KERNEL_INTERFACE:
+		err = m.KernelInterface.validate()
+	case LocalMechanismType_MEMIF:
+		err = m.Memif.validate()
+	case LocalMechanismType_VHOST_USER:
+		err = m.VhostUser.validate()
+	case LocalMechanismType_SRIOV_VF:
+		err = m.SriovVf.validate()
+	}
+	if err != nil {
+		return fmt.Errorf("mechanism %s: %s", m.Type, err)
+	}
+	return nil
+}
+
+// Validate checks the type of the remote mechanism and its parameters. Only
+// the parameters of its type may be set, parameters left empty are filled in
+// by the dataplane.
+func (m *RemoteMechanism) Validate() error {
+	mechanism, err := m.MechanismType()
+	if err != nil {
+		return err
+	}
+	if mechanism == RemoteMechanismType_REMOTE_MECHANISM_UNSPECIFIED {
+		return fmt.Errorf("mechanism type is required")
+	}
+	set := []bool{
+		RemoteMechanismType_VXLAN:         m.Vxlan != nil,
+		RemoteMechanismType_GRE:           m.Gre != nil,
+		RemoteMechanismType_MPLS_OVER_GRE: m.MplsOverGre != nil,
+		RemoteMechanismType_SRV6:          m.Srv6 != nil,
+	}
+	for other, isSet := range set {
+		if isSet && RemoteMechanismType(other) != mechanism {
+			return fmt.Errorf("%s parameters are not allowed for mechanism %s", RemoteMechanismType(other).Name(), m.Type)
+		}
+	}
+
+	switch mechanism {
+	case RemoteMechanismType_VXLAN:
+		err = m.Vxlan.validate()
+	case RemoteMechanismType_GRE:
+		err = m.Gre.validate()
+	case RemoteMechanismType_MPLS_OVER_GRE:
+		err = m.MplsOverGre.validate()
+	case RemoteMechanismType_SRV6:
+		err = m.Srv6.validate()
+	}
+	if err != nil {
+		return fmt.Errorf("mechanism %s: %s", m.Type, err)
+	}
+	return nil
+}
+
+// validate checks the name of the interface, which must be a valid Linux
+// interface name, and the path of the network namespace.
+func (p *KernelInterfaceParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Name != "" {
+		if len(p.Name) > MaxInterfaceNameLength || p.Name == "." || p.Name == ".." ||
+			strings.ContainsAny(p.Name, "/: \t\n") {
+			return fmt.Errorf("invalid interface name %q, expected at most %d characters other than '/', ':' and whitespace",
+				p.Name, MaxInterfaceNameLength)
+		}
+	}
+	return validatePath("netns", p.Netns)
+}
+
+// validate checks the path of the socket.
+func (p *MemifParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	return validatePath("socket", p.Socket)
+}
+
+// validate checks the path of the socket.
+func (p *VhostUserParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	return validatePath("socket", p.Socket)
+}
+
+// validate checks the PCI address and the VLAN ID of the VF.
+func (p *SriovVfParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.PciAddress != "" && !pciAddressRegexp.MatchString(p.PciAddress) {
+		return fmt.Errorf("invalid pciAddress %q, expected domain:bus:device.function", p.PciAddress)
+	}
+	if p.Vlan > MaxVLAN {
+		return fmt.Errorf("vlan must be at most %d, got %d", MaxVLAN, p.Vlan)
+	}
+	return nil
+}
+
+// validate checks the ends of the tunnel, its VNI and its port.
+func (p *VxlanParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := validateTunnel(p.SrcIp, p.DstIp); err != nil {
+		return err
+	}
+	if p.Vni > MaxVNI {
+		return fmt.Errorf("vni must be at most %d, got %d", MaxVNI, p.Vni)
+	}
+	if p.DstPort > MaxPort {
+		return fmt.Errorf("dstPort must be at most %d, got %d", MaxPort, p.DstPort)
+	}
+	return nil
+}
+
+// validate checks the ends of the tunnel.
+func (p *GreParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	return validateTunnel(p.SrcIp, p.DstIp)
+}
+
+// validate checks the ends of the tunnel and the label, 0 standing for a
+// label assigned by the dataplane.
+func (p *MplsOverGreParameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := validateTunnel(p.SrcIp, p.DstIp); err != nil {
+		return err
+	}
+	if p.Label != 0 && (p.Label < MinMPLSLabel || p.Label > MaxMPLSLabel) {
+		return fmt.Errorf("label must be between %d and %d, got %d", MinMPLSLabel, MaxMPLSLabel, p.Label)
+	}
+	return nil
+}
+
+// validate checks that the SIDs are IPv6 addresses.
+func (p *Srv6Parameters) validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, sid := range []struct{ name, value string }{
+		{"localSid", p.LocalSid},
+		{"remoteSid", p.RemoteSid},
+	} {
+		if sid.value == "" {
+			continue
+		}
+		if ip := net.ParseIP(sid.value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid %s %q, expected an IPv6 address", sid.name, sid.value)
+		}
+	}
+	return nil
+}
+
+// validatePath checks that a path, if set, is absolute.
+func validatePath(name, value string) error {
+	if value != "" && !path.IsAbs(value) {
+		return fmt.Errorf("%s must be an absolute path, got %q", name, value)
+	}
+	return nil
+}
+
+// validateTunnel checks that the ends of a tunnel, if set, are IP addresses
+// of the same family.
+func validateTunnel(src, dst string) error {
+	var ips []net.IP
+	for _, end := range []struct{ name, value string }{
+		{"srcIp", src},
+		{"dstIp", dst},
+	} {
+		if end.value == "" {
+			continue
+		}
+		ip := net.ParseIP(end.value)
+		if ip == nil {
+			return fmt.Errorf("invalid %s %q, expected an IP address", end.name, end.value)
+		}
+		ips = append(ips, ip)
+	}
+	if len(ips) == 2 && (ips[0].To4() == nil) != (ips[1].To4() == nil) {
+		return fmt.Errorf("srcIp %s and dstIp %s are not of the same family", src, dst)
+	}
+	return nil
+}
@@ -18,9 +18,141 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Payload is the type of traffic carried by a channel. Channels refer to
+// payloads by their name, see payload.go, so that the objects keep a readable
+// encoding.
+type Payload int32
+
+const (
+	Payload_PAYLOAD_UNSPECIFIED Payload = 0
+	// Ethernet frames
+	Payload_ETHERNET Payload = 1
+	// IPv4 packets
+	Payload_IPV4 Payload = 2
+	// IPv6 packets
+	Payload_IPV6 Payload = 3
+	// MPLS labeled packets
+	Payload_MPLS Payload = 4
+	// Raw L2 frames tagged with the VLAN ID of the channel
+	Payload_L2_VLAN Payload = 5
+)
+
+var Payload_name = map[int32]string{
+	0: "PAYLOAD_UNSPECIFIED",
+	1: "ETHERNET",
+	2: "IPV4",
+	3: "IPV6",
+	4: "MPLS",
+	5: "L2_VLAN",
+}
+var Payload_value = map[string]int32{
+	"PAYLOAD_UNSPECIFIED": 0,
+	"ETHERNET":            1,
+	"IPV4":                2,
+	"IPV6":                3,
+	"MPLS":                4,
+	"L2_VLAN":             5,
+}
+
+func (x Payload) String() string {
+	return proto.EnumName(Payload_name, int32(x))
+}
+func (Payload) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{0}
+}
+
+// LocalMechanismType enumerates the mechanisms plugging a connection into a
+// pod, on the node of the pod.
+type LocalMechanismType int32
+
+const (
+	LocalMechanismType_LOCAL_MECHANISM_UNSPECIFIED LocalMechanismType = 0
+	LocalMechanismType_KERNEL_INTERFACE            LocalMechanismType = 1
+	LocalMechanismType_MEMIF                       LocalMechanismType = 2
+	LocalMechanismType_VHOST_USER                  LocalMechanismType = 3
+	LocalMechanismType_SRIOV_VF                    LocalMechanismType = 4
+)
+
+var LocalMechanismType_name = map[int32]string{
+	0: "LOCAL_MECHANISM_UNSPECIFIED",
+	1: "KERNEL_INTERFACE",
+	2: "MEMIF",
+	3: "VHOST_USER",
+	4: "SRIOV_VF",
+}
+var LocalMechanismType_value = map[string]int32{
+	"LOCAL_MECHANISM_UNSPECIFIED": 0,
+	"KERNEL_INTERFACE":            1,
+	"MEMIF":                       2,
+	"VHOST_USER":                  3,
+	"SRIOV_VF":                    4,
+}
+
+func (x LocalMechanismType) String() string {
+	return proto.EnumName(LocalMechanismType_name, int32(x))
+}
+func (LocalMechanismType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{1}
+}
+
+// RemoteMechanismType enumerates the mechanisms carrying a connection between
+// the node of the client and the node of the endpoint.
+type RemoteMechanismType int32
+
+const (
+	RemoteMechanismType_REMOTE_MECHANISM_UNSPECIFIED RemoteMechanismType = 0
+	RemoteMechanismType_VXLAN                        RemoteMechanismType = 1
+	RemoteMechanismType_GRE                          RemoteMechanismType = 2
+	RemoteMechanismType_MPLS_OVER_GRE                RemoteMechanismType = 3
+	RemoteMechanismType_SRV6                         RemoteMechanismType = 4
+)
+
+var RemoteMechanismType_name = map[int32]string{
+	0: "REMOTE_MECHANISM_UNSPECIFIED",
+	1: "VXLAN",
+	2: "GRE",
+	3: "MPLS_OVER_GRE",
+	4: "SRV6",
+}
+var RemoteMechanismType_value = map[string]int32{
+	"REMOTE_MECHANISM_UNSPECIFIED": 0,
+	"VXLAN":                        1,
+	"GRE":                          2,
+	"MPLS_OVER_GRE":                3,
+	"SRV6":                         4,
+}
+
+func (x RemoteMechanismType) String() string {
+	return proto.EnumName(RemoteMechanismType_name, int32(x))
+}
+func (RemoteMechanismType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{2}
+}
+
 type NetworkServiceEndpoint struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Uuid                 string   `protobuf:"bytes,2,opt,name=uuid" json:"uuid,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Uuid string `protobuf:"bytes,2,opt,name=uuid" json:"uuid,omitempty"`
+	// node is the name of the node the endpoint runs on
+	Node string `protobuf:"bytes,3,opt,name=node" json:"node,omitempty"`
+	// pod references the pod providing the endpoint, in the namespace of the
+	// endpoint
+	Pod *PodReference `protobuf:"bytes,4,opt,name=pod" json:"pod,omitempty"`
+	// socket is the path of the NSM socket of the workspace of the pod,
+	// through which the endpoint is reached
+	Socket string `protobuf:"bytes,5,opt,name=socket" json:"socket,omitempty"`
+	// labels are advertised by the endpoint to the clients of its
+	// connections. Services select endpoints by these labels along with the
+	// labels of their object, which win when both set a key.
+	Labels map[string]string `protobuf:"bytes,6,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// mechanisms lists the mechanisms the endpoint supports, in order of
+	// preference, e.g. "kernel-interface" or "memif"
+	Mechanisms []string `protobuf:"bytes,7,rep,name=mechanisms" json:"mechanisms,omitempty"`
+	// capacity is the maximum number of connections the endpoint accepts,
+	// 0 for unlimited
+	Capacity uint32 `protobuf:"varint,8,opt,name=capacity" json:"capacity,omitempty"`
+	// remoteMechanisms lists the mechanisms carrying connections from
+	// clients on other nodes the endpoint supports, e.g. "vxlan"
+	RemoteMechanisms     []string `protobuf:"bytes,9,rep,name=remoteMechanisms" json:"remoteMechanisms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -30,7 +162,7 @@ func (m *NetworkServiceEndpoint) Reset()         { *m = NetworkServiceEndpoint{}
 func (m *NetworkServiceEndpoint) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpoint) ProtoMessage()    {}
 func (*NetworkServiceEndpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_netmesh_9e195ebaabd0ca3b, []int{0}
+	return fileDescriptor_netmesh_c650553582adbc10, []int{0}
 }
 func (m *NetworkServiceEndpoint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkServiceEndpoint.Unmarshal(m, b)
@@ -64,21 +196,244 @@ func (m *NetworkServiceEndpoint) GetUuid() string {
 	return ""
 }
 
+func (m *NetworkServiceEndpoint) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NetworkServiceEndpoint) GetPod() *PodReference {
+	if m != nil {
+		return m.Pod
+	}
+	return nil
+}
+
+func (m *NetworkServiceEndpoint) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *NetworkServiceEndpoint) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *NetworkServiceEndpoint) GetMechanisms() []string {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *NetworkServiceEndpoint) GetCapacity() uint32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *NetworkServiceEndpoint) GetRemoteMechanisms() []string {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
+// PodReference references a pod of the namespace of the referencing object.
+// The UID tells apart pods which reuse the same name.
+type PodReference struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Uid                  string   `protobuf:"bytes,2,opt,name=uid" json:"uid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodReference) Reset()         { *m = PodReference{} }
+func (m *PodReference) String() string { return proto.CompactTextString(m) }
+func (*PodReference) ProtoMessage()    {}
+func (*PodReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{1}
+}
+func (m *PodReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodReference.Unmarshal(m, b)
+}
+func (m *PodReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodReference.Marshal(b, m, deterministic)
+}
+func (dst *PodReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodReference.Merge(dst, src)
+}
+func (m *PodReference) XXX_Size() int {
+	return xxx_messageInfo_PodReference.Size(m)
+}
+func (m *PodReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodReference proto.InternalMessageInfo
+
+func (m *PodReference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PodReference) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+// LabelSelectorRequirement is a requirement on the value of a label. The
+// operator is one of In, NotIn, Exists and DoesNotExist. Values must be empty
+// for Exists and DoesNotExist, and non empty otherwise.
+//
+// The fields are named after their Kubernetes counterparts, as the JSON
+// encoding of the objects follows the names of the fields.
+type LabelSelectorRequirement struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Operator             string   `protobuf:"bytes,2,opt,name=operator" json:"operator,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelSelectorRequirement) Reset()         { *m = LabelSelectorRequirement{} }
+func (m *LabelSelectorRequirement) String() string { return proto.CompactTextString(m) }
+func (*LabelSelectorRequirement) ProtoMessage()    {}
+func (*LabelSelectorRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{2}
+}
+func (m *LabelSelectorRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelSelectorRequirement.Unmarshal(m, b)
+}
+func (m *LabelSelectorRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelSelectorRequirement.Marshal(b, m, deterministic)
+}
+func (dst *LabelSelectorRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelSelectorRequirement.Merge(dst, src)
+}
+func (m *LabelSelectorRequirement) XXX_Size() int {
+	return xxx_messageInfo_LabelSelectorRequirement.Size(m)
+}
+func (m *LabelSelectorRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelSelectorRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelSelectorRequirement proto.InternalMessageInfo
+
+func (m *LabelSelectorRequirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// LabelSelector selects objects by their labels. The requirements of
+// matchLabels and matchExpressions are ANDed, an empty selector matches all
+// objects.
+type LabelSelector struct {
+	MatchLabels          map[string]string           `protobuf:"bytes,1,rep,name=matchLabels" json:"matchLabels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MatchExpressions     []*LabelSelectorRequirement `protobuf:"bytes,2,rep,name=matchExpressions" json:"matchExpressions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *LabelSelector) Reset()         { *m = LabelSelector{} }
+func (m *LabelSelector) String() string { return proto.CompactTextString(m) }
+func (*LabelSelector) ProtoMessage()    {}
+func (*LabelSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{3}
+}
+func (m *LabelSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelSelector.Unmarshal(m, b)
+}
+func (m *LabelSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelSelector.Marshal(b, m, deterministic)
+}
+func (dst *LabelSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelSelector.Merge(dst, src)
+}
+func (m *LabelSelector) XXX_Size() int {
+	return xxx_messageInfo_LabelSelector.Size(m)
+}
+func (m *LabelSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelSelector proto.InternalMessageInfo
+
+func (m *LabelSelector) GetMatchLabels() map[string]string {
+	if m != nil {
+		return m.MatchLabels
+	}
+	return nil
+}
+
+func (m *LabelSelector) GetMatchExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
 type NetworkService struct {
-	Name                 string                           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Uuid                 string                           `protobuf:"bytes,2,opt,name=uuid" json:"uuid,omitempty"`
-	Selector             string                           `protobuf:"bytes,3,opt,name=selector" json:"selector,omitempty"`
-	Channels             []*NetworkService_NetmeshChannel `protobuf:"bytes,4,rep,name=channels" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Uuid string `protobuf:"bytes,2,opt,name=uuid" json:"uuid,omitempty"`
+	// selector is a Kubernetes label selector in its string form, e.g.
+	// "routing" or "env in (prod, staging)"
+	Selector string                           `protobuf:"bytes,3,opt,name=selector" json:"selector,omitempty"`
+	Channels []*NetworkService_NetmeshChannel `protobuf:"bytes,4,rep,name=channels" json:"channels,omitempty"`
+	// labelSelector selects the endpoints of the service, in addition to
+	// selector
+	LabelSelector *LabelSelector `protobuf:"bytes,5,opt,name=labelSelector" json:"labelSelector,omitempty"`
+	// exportTo lists the namespaces allowed to import the service, the
+	// endpoints of a service are only selectable from its own namespace
+	// otherwise
+	ExportTo *ExportPolicy `protobuf:"bytes,6,opt,name=exportTo" json:"exportTo,omitempty"`
+	// imports lists services of other namespaces whose endpoints are
+	// selected by the service in addition to the endpoints of its own
+	// namespace
+	Imports []*ServiceReference `protobuf:"bytes,7,rep,name=imports" json:"imports,omitempty"`
+	// prefixes are the pools of the addresses of the connections through the
+	// service, in their CIDR form, e.g. "10.60.0.0/16". Each connection gets
+	// a /30 of an IPv4 prefix or a /127 of an IPv6 prefix, see pkg/nsm/ipam
+	Prefixes             []string `protobuf:"bytes,8,rep,name=prefixes" json:"prefixes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *NetworkService) Reset()         { *m = NetworkService{} }
 func (m *NetworkService) String() string { return proto.CompactTextString(m) }
 func (*NetworkService) ProtoMessage()    {}
 func (*NetworkService) Descriptor() ([]byte, []int) {
-	return fileDescriptor_netmesh_9e195ebaabd0ca3b, []int{1}
+	return fileDescriptor_netmesh_c650553582adbc10, []int{4}
 }
 func (m *NetworkService) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkService.Unmarshal(m, b)
@@ -126,9 +481,41 @@ func (m *NetworkService) GetChannels() []*NetworkService_NetmeshChannel {
 	return nil
 }
 
+func (m *NetworkService) GetLabelSelector() *LabelSelector {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *NetworkService) GetExportTo() *ExportPolicy {
+	if m != nil {
+		return m.ExportTo
+	}
+	return nil
+}
+
+func (m *NetworkService) GetImports() []*ServiceReference {
+	if m != nil {
+		return m.Imports
+	}
+	return nil
+}
+
+func (m *NetworkService) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
 type NetworkService_NetmeshChannel struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Payload              string   `protobuf:"bytes,2,opt,name=payload" json:"payload,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// payload is the name of the Payload carried by the channel, see
+	// payload.go, e.g. "ethernet" or "l2-vlan"
+	Payload string `protobuf:"bytes,2,opt,name=payload" json:"payload,omitempty"`
+	// vlan is the VLAN ID of channels carrying the L2_VLAN payload
+	Vlan                 uint32   `protobuf:"varint,3,opt,name=vlan" json:"vlan,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -138,7 +525,7 @@ func (m *NetworkService_NetmeshChannel) Reset()         { *m = NetworkService_Ne
 func (m *NetworkService_NetmeshChannel) String() string { return proto.CompactTextString(m) }
 func (*NetworkService_NetmeshChannel) ProtoMessage()    {}
 func (*NetworkService_NetmeshChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_netmesh_9e195ebaabd0ca3b, []int{1, 0}
+	return fileDescriptor_netmesh_c650553582adbc10, []int{4, 0}
 }
 func (m *NetworkService_NetmeshChannel) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkService_NetmeshChannel.Unmarshal(m, b)
@@ -172,26 +559,980 @@ func (m *NetworkService_NetmeshChannel) GetPayload() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*NetworkServiceEndpoint)(nil), "netmesh.NetworkServiceEndpoint")
-	proto.RegisterType((*NetworkService)(nil), "netmesh.NetworkService")
-	proto.RegisterType((*NetworkService_NetmeshChannel)(nil), "netmesh.NetworkService.NetmeshChannel")
+func (m *NetworkService_NetmeshChannel) GetVlan() uint32 {
+	if m != nil {
+		return m.Vlan
+	}
+	return 0
+}
+
+// ExportPolicy selects namespaces by name or by their labels, a namespace
+// matching either is selected.
+type ExportPolicy struct {
+	Namespaces           []string       `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty"`
+	NamespaceSelector    *LabelSelector `protobuf:"bytes,2,opt,name=namespaceSelector" json:"namespaceSelector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ExportPolicy) Reset()         { *m = ExportPolicy{} }
+func (m *ExportPolicy) String() string { return proto.CompactTextString(m) }
+func (*ExportPolicy) ProtoMessage()    {}
+func (*ExportPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{5}
+}
+func (m *ExportPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportPolicy.Unmarshal(m, b)
+}
+func (m *ExportPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportPolicy.Marshal(b, m, deterministic)
+}
+func (dst *ExportPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportPolicy.Merge(dst, src)
+}
+func (m *ExportPolicy) XXX_Size() int {
+	return xxx_messageInfo_ExportPolicy.Size(m)
+}
+func (m *ExportPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportPolicy proto.InternalMessageInfo
+
+func (m *ExportPolicy) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *ExportPolicy) GetNamespaceSelector() *LabelSelector {
+	if m != nil {
+		return m.NamespaceSelector
+	}
+	return nil
+}
+
+// ServiceReference references a NetworkService of another namespace.
+type ServiceReference struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceReference) Reset()         { *m = ServiceReference{} }
+func (m *ServiceReference) String() string { return proto.CompactTextString(m) }
+func (*ServiceReference) ProtoMessage()    {}
+func (*ServiceReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{6}
+}
+func (m *ServiceReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceReference.Unmarshal(m, b)
+}
+func (m *ServiceReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceReference.Marshal(b, m, deterministic)
+}
+func (dst *ServiceReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceReference.Merge(dst, src)
+}
+func (m *ServiceReference) XXX_Size() int {
+	return xxx_messageInfo_ServiceReference.Size(m)
+}
+func (m *ServiceReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceReference proto.InternalMessageInfo
+
+func (m *ServiceReference) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ServiceReference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// KernelInterfaceParameters are the parameters of a kernel interface moved
+// into the network namespace of the pod.
+type KernelInterfaceParameters struct {
+	// name of the interface in the pod
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// netns is the path of the network namespace of the pod
+	Netns                string   `protobuf:"bytes,2,opt,name=netns" json:"netns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KernelInterfaceParameters) Reset()         { *m = KernelInterfaceParameters{} }
+func (m *KernelInterfaceParameters) String() string { return proto.CompactTextString(m) }
+func (*KernelInterfaceParameters) ProtoMessage()    {}
+func (*KernelInterfaceParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{7}
+}
+func (m *KernelInterfaceParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KernelInterfaceParameters.Unmarshal(m, b)
+}
+func (m *KernelInterfaceParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KernelInterfaceParameters.Marshal(b, m, deterministic)
+}
+func (dst *KernelInterfaceParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KernelInterfaceParameters.Merge(dst, src)
+}
+func (m *KernelInterfaceParameters) XXX_Size() int {
+	return xxx_messageInfo_KernelInterfaceParameters.Size(m)
+}
+func (m *KernelInterfaceParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_KernelInterfaceParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KernelInterfaceParameters proto.InternalMessageInfo
+
+func (m *KernelInterfaceParameters) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KernelInterfaceParameters) GetNetns() string {
+	if m != nil {
+		return m.Netns
+	}
+	return ""
+}
+
+// MemifParameters are the parameters of a shared memory packet interface.
+type MemifParameters struct {
+	// socket is the path of the control socket of the interface
+	Socket string `protobuf:"bytes,1,opt,name=socket" json:"socket,omitempty"`
+	// id distinguishes the interfaces sharing the socket
+	Id uint32 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	// master is true when the pod is the master end of the interface
+	Master               bool     `protobuf:"varint,3,opt,name=master" json:"master,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemifParameters) Reset()         { *m = MemifParameters{} }
+func (m *MemifParameters) String() string { return proto.CompactTextString(m) }
+func (*MemifParameters) ProtoMessage()    {}
+func (*MemifParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{8}
+}
+func (m *MemifParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemifParameters.Unmarshal(m, b)
+}
+func (m *MemifParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemifParameters.Marshal(b, m, deterministic)
+}
+func (dst *MemifParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemifParameters.Merge(dst, src)
+}
+func (m *MemifParameters) XXX_Size() int {
+	return xxx_messageInfo_MemifParameters.Size(m)
+}
+func (m *MemifParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemifParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemifParameters proto.InternalMessageInfo
+
+func (m *MemifParameters) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *MemifParameters) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *MemifParameters) GetMaster() bool {
+	if m != nil {
+		return m.Master
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("netmesh.proto", fileDescriptor_netmesh_9e195ebaabd0ca3b) }
-
-var fileDescriptor_netmesh_9e195ebaabd0ca3b = []byte{
-	// 187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4b, 0x2d, 0xc9,
-	0x4d, 0x2d, 0xce, 0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0x1c, 0xb8,
-	0xc4, 0xfc, 0x52, 0x4b, 0xca, 0xf3, 0x8b, 0xb2, 0x83, 0x53, 0x8b, 0xca, 0x32, 0x93, 0x53, 0x5d,
-	0xf3, 0x52, 0x0a, 0xf2, 0x33, 0xf3, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0xf2, 0x12, 0x73, 0x53, 0x25,
-	0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0x90, 0x58, 0x69, 0x69, 0x66, 0x8a, 0x04, 0x13,
-	0x44, 0x0c, 0xc4, 0x56, 0xba, 0xc1, 0xc8, 0xc5, 0x87, 0x6a, 0x04, 0xb1, 0x5a, 0x85, 0xa4, 0xb8,
-	0x38, 0x8a, 0x53, 0x73, 0x52, 0x93, 0x4b, 0xf2, 0x8b, 0x24, 0x98, 0xc1, 0xe2, 0x70, 0xbe, 0x90,
-	0x13, 0x17, 0x47, 0x72, 0x46, 0x62, 0x5e, 0x5e, 0x6a, 0x4e, 0xb1, 0x04, 0x8b, 0x02, 0xb3, 0x06,
-	0xb7, 0x91, 0x9a, 0x1e, 0xcc, 0x0f, 0xa8, 0xd6, 0x81, 0xb8, 0x20, 0x61, 0x67, 0x88, 0xf2, 0x20,
-	0xb8, 0x3e, 0x29, 0x3b, 0xb0, 0xcb, 0x90, 0xe4, 0xb0, 0xba, 0x4c, 0x82, 0x8b, 0xbd, 0x20, 0xb1,
-	0x32, 0x27, 0x3f, 0x11, 0xe6, 0x38, 0x18, 0x37, 0x89, 0x0d, 0x1c, 0x58, 0xc6, 0x80, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x0f, 0x2a, 0xe9, 0xb2, 0x3d, 0x01, 0x00, 0x00,
+// VhostUserParameters are the parameters of a vhost-user interface.
+type VhostUserParameters struct {
+	// socket is the path of the vhost-user socket
+	Socket string `protobuf:"bytes,1,opt,name=socket" json:"socket,omitempty"`
+	// server is true when the pod creates the socket
+	Server               bool     `protobuf:"varint,2,opt,name=server" json:"server,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VhostUserParameters) Reset()         { *m = VhostUserParameters{} }
+func (m *VhostUserParameters) String() string { return proto.CompactTextString(m) }
+func (*VhostUserParameters) ProtoMessage()    {}
+func (*VhostUserParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{9}
+}
+func (m *VhostUserParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VhostUserParameters.Unmarshal(m, b)
+}
+func (m *VhostUserParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VhostUserParameters.Marshal(b, m, deterministic)
+}
+func (dst *VhostUserParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VhostUserParameters.Merge(dst, src)
+}
+func (m *VhostUserParameters) XXX_Size() int {
+	return xxx_messageInfo_VhostUserParameters.Size(m)
+}
+func (m *VhostUserParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_VhostUserParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VhostUserParameters proto.InternalMessageInfo
+
+func (m *VhostUserParameters) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *VhostUserParameters) GetServer() bool {
+	if m != nil {
+		return m.Server
+	}
+	return false
+}
+
+// SriovVfParameters are the parameters of an SR-IOV virtual function passed to
+// the pod.
+type SriovVfParameters struct {
+	// pfName is the name of the physical function the VF belongs to
+	PfName  string `protobuf:"bytes,1,opt,name=pfName" json:"pfName,omitempty"`
+	VfIndex uint32 `protobuf:"varint,2,opt,name=vfIndex" json:"vfIndex,omitempty"`
+	// pciAddress is the PCI address of the VF, e.g. 0000:03:02.1
+	PciAddress string `protobuf:"bytes,3,opt,name=pciAddress" json:"pciAddress,omitempty"`
+	// vlan tags the traffic of the VF, 0 for untagged traffic
+	Vlan                 uint32   `protobuf:"varint,4,opt,name=vlan" json:"vlan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SriovVfParameters) Reset()         { *m = SriovVfParameters{} }
+func (m *SriovVfParameters) String() string { return proto.CompactTextString(m) }
+func (*SriovVfParameters) ProtoMessage()    {}
+func (*SriovVfParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{10}
+}
+func (m *SriovVfParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SriovVfParameters.Unmarshal(m, b)
+}
+func (m *SriovVfParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SriovVfParameters.Marshal(b, m, deterministic)
+}
+func (dst *SriovVfParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SriovVfParameters.Merge(dst, src)
+}
+func (m *SriovVfParameters) XXX_Size() int {
+	return xxx_messageInfo_SriovVfParameters.Size(m)
+}
+func (m *SriovVfParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_SriovVfParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SriovVfParameters proto.InternalMessageInfo
+
+func (m *SriovVfParameters) GetPfName() string {
+	if m != nil {
+		return m.PfName
+	}
+	return ""
+}
+
+func (m *SriovVfParameters) GetVfIndex() uint32 {
+	if m != nil {
+		return m.VfIndex
+	}
+	return 0
+}
+
+func (m *SriovVfParameters) GetPciAddress() string {
+	if m != nil {
+		return m.PciAddress
+	}
+	return ""
+}
+
+func (m *SriovVfParameters) GetVlan() uint32 {
+	if m != nil {
+		return m.Vlan
+	}
+	return 0
+}
+
+// VxlanParameters are the parameters of a VXLAN tunnel.
+type VxlanParameters struct {
+	SrcIp string `protobuf:"bytes,1,opt,name=srcIp" json:"srcIp,omitempty"`
+	DstIp string `protobuf:"bytes,2,opt,name=dstIp" json:"dstIp,omitempty"`
+	Vni   uint32 `protobuf:"varint,3,opt,name=vni" json:"vni,omitempty"`
+	// dstPort is the UDP port of the tunnel, 0 for the IANA port 4789
+	DstPort              uint32   `protobuf:"varint,4,opt,name=dstPort" json:"dstPort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VxlanParameters) Reset()         { *m = VxlanParameters{} }
+func (m *VxlanParameters) String() string { return proto.CompactTextString(m) }
+func (*VxlanParameters) ProtoMessage()    {}
+func (*VxlanParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{11}
+}
+func (m *VxlanParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VxlanParameters.Unmarshal(m, b)
+}
+func (m *VxlanParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VxlanParameters.Marshal(b, m, deterministic)
+}
+func (dst *VxlanParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VxlanParameters.Merge(dst, src)
+}
+func (m *VxlanParameters) XXX_Size() int {
+	return xxx_messageInfo_VxlanParameters.Size(m)
+}
+func (m *VxlanParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_VxlanParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VxlanParameters proto.InternalMessageInfo
+
+func (m *VxlanParameters) GetSrcIp() string {
+	if m != nil {
+		return m.SrcIp
+	}
+	return ""
+}
+
+func (m *VxlanParameters) GetDstIp() string {
+	if m != nil {
+		return m.DstIp
+	}
+	return ""
+}
+
+func (m *VxlanParameters) GetVni() uint32 {
+	if m != nil {
+		return m.Vni
+	}
+	return 0
+}
+
+func (m *VxlanParameters) GetDstPort() uint32 {
+	if m != nil {
+		return m.DstPort
+	}
+	return 0
+}
+
+// GreParameters are the parameters of a GRE tunnel.
+type GreParameters struct {
+	SrcIp string `protobuf:"bytes,1,opt,name=srcIp" json:"srcIp,omitempty"`
+	DstIp string `protobuf:"bytes,2,opt,name=dstIp" json:"dstIp,omitempty"`
+	// key identifies the connection within the tunnel, 0 for none
+	Key                  uint32   `protobuf:"varint,3,opt,name=key" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GreParameters) Reset()         { *m = GreParameters{} }
+func (m *GreParameters) String() string { return proto.CompactTextString(m) }
+func (*GreParameters) ProtoMessage()    {}
+func (*GreParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{12}
+}
+func (m *GreParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GreParameters.Unmarshal(m, b)
+}
+func (m *GreParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GreParameters.Marshal(b, m, deterministic)
+}
+func (dst *GreParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GreParameters.Merge(dst, src)
+}
+func (m *GreParameters) XXX_Size() int {
+	return xxx_messageInfo_GreParameters.Size(m)
+}
+func (m *GreParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_GreParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GreParameters proto.InternalMessageInfo
+
+func (m *GreParameters) GetSrcIp() string {
+	if m != nil {
+		return m.SrcIp
+	}
+	return ""
+}
+
+func (m *GreParameters) GetDstIp() string {
+	if m != nil {
+		return m.DstIp
+	}
+	return ""
+}
+
+func (m *GreParameters) GetKey() uint32 {
+	if m != nil {
+		return m.Key
+	}
+	return 0
+}
+
+// MplsOverGreParameters are the parameters of an MPLS over GRE tunnel.
+type MplsOverGreParameters struct {
+	SrcIp string `protobuf:"bytes,1,opt,name=srcIp" json:"srcIp,omitempty"`
+	DstIp string `protobuf:"bytes,2,opt,name=dstIp" json:"dstIp,omitempty"`
+	// label identifies the connection within the tunnel
+	Label                uint32   `protobuf:"varint,3,opt,name=label" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MplsOverGreParameters) Reset()         { *m = MplsOverGreParameters{} }
+func (m *MplsOverGreParameters) String() string { return proto.CompactTextString(m) }
+func (*MplsOverGreParameters) ProtoMessage()    {}
+func (*MplsOverGreParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{13}
+}
+func (m *MplsOverGreParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MplsOverGreParameters.Unmarshal(m, b)
+}
+func (m *MplsOverGreParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MplsOverGreParameters.Marshal(b, m, deterministic)
+}
+func (dst *MplsOverGreParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MplsOverGreParameters.Merge(dst, src)
+}
+func (m *MplsOverGreParameters) XXX_Size() int {
+	return xxx_messageInfo_MplsOverGreParameters.Size(m)
+}
+func (m *MplsOverGreParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_MplsOverGreParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MplsOverGreParameters proto.InternalMessageInfo
+
+func (m *MplsOverGreParameters) GetSrcIp() string {
+	if m != nil {
+		return m.SrcIp
+	}
+	return ""
+}
+
+func (m *MplsOverGreParameters) GetDstIp() string {
+	if m != nil {
+		return m.DstIp
+	}
+	return ""
+}
+
+func (m *MplsOverGreParameters) GetLabel() uint32 {
+	if m != nil {
+		return m.Label
+	}
+	return 0
+}
+
+// Srv6Parameters are the parameters of an SRv6 path, the SIDs are IPv6
+// addresses.
+type Srv6Parameters struct {
+	LocalSid             string   `protobuf:"bytes,1,opt,name=localSid" json:"localSid,omitempty"`
+	RemoteSid            string   `protobuf:"bytes,2,opt,name=remoteSid" json:"remoteSid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Srv6Parameters) Reset()         { *m = Srv6Parameters{} }
+func (m *Srv6Parameters) String() string { return proto.CompactTextString(m) }
+func (*Srv6Parameters) ProtoMessage()    {}
+func (*Srv6Parameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{14}
+}
+func (m *Srv6Parameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Srv6Parameters.Unmarshal(m, b)
+}
+func (m *Srv6Parameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Srv6Parameters.Marshal(b, m, deterministic)
+}
+func (dst *Srv6Parameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Srv6Parameters.Merge(dst, src)
+}
+func (m *Srv6Parameters) XXX_Size() int {
+	return xxx_messageInfo_Srv6Parameters.Size(m)
+}
+func (m *Srv6Parameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_Srv6Parameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Srv6Parameters proto.InternalMessageInfo
+
+func (m *Srv6Parameters) GetLocalSid() string {
+	if m != nil {
+		return m.LocalSid
+	}
+	return ""
+}
+
+func (m *Srv6Parameters) GetRemoteSid() string {
+	if m != nil {
+		return m.RemoteSid
+	}
+	return ""
+}
+
+// LocalMechanism plugs a connection into a pod. type is the name of a
+// LocalMechanismType, e.g. "kernel-interface", and only the parameters of that
+// type may be set. Parameters left empty are filled in by the dataplane.
+type LocalMechanism struct {
+	Type                 string                     `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	KernelInterface      *KernelInterfaceParameters `protobuf:"bytes,2,opt,name=kernelInterface" json:"kernelInterface,omitempty"`
+	Memif                *MemifParameters           `protobuf:"bytes,3,opt,name=memif" json:"memif,omitempty"`
+	VhostUser            *VhostUserParameters       `protobuf:"bytes,4,opt,name=vhostUser" json:"vhostUser,omitempty"`
+	SriovVf              *SriovVfParameters         `protobuf:"bytes,5,opt,name=sriovVf" json:"sriovVf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *LocalMechanism) Reset()         { *m = LocalMechanism{} }
+func (m *LocalMechanism) String() string { return proto.CompactTextString(m) }
+func (*LocalMechanism) ProtoMessage()    {}
+func (*LocalMechanism) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{15}
+}
+func (m *LocalMechanism) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalMechanism.Unmarshal(m, b)
+}
+func (m *LocalMechanism) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalMechanism.Marshal(b, m, deterministic)
+}
+func (dst *LocalMechanism) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalMechanism.Merge(dst, src)
+}
+func (m *LocalMechanism) XXX_Size() int {
+	return xxx_messageInfo_LocalMechanism.Size(m)
+}
+func (m *LocalMechanism) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalMechanism.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalMechanism proto.InternalMessageInfo
+
+func (m *LocalMechanism) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LocalMechanism) GetKernelInterface() *KernelInterfaceParameters {
+	if m != nil {
+		return m.KernelInterface
+	}
+	return nil
+}
+
+func (m *LocalMechanism) GetMemif() *MemifParameters {
+	if m != nil {
+		return m.Memif
+	}
+	return nil
+}
+
+func (m *LocalMechanism) GetVhostUser() *VhostUserParameters {
+	if m != nil {
+		return m.VhostUser
+	}
+	return nil
+}
+
+func (m *LocalMechanism) GetSriovVf() *SriovVfParameters {
+	if m != nil {
+		return m.SriovVf
+	}
+	return nil
+}
+
+// RemoteMechanism carries a connection between two nodes. type is the name of
+// a RemoteMechanismType, e.g. "vxlan", and only the parameters of that type
+// may be set.
+type RemoteMechanism struct {
+	Type                 string                 `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Vxlan                *VxlanParameters       `protobuf:"bytes,2,opt,name=vxlan" json:"vxlan,omitempty"`
+	Gre                  *GreParameters         `protobuf:"bytes,3,opt,name=gre" json:"gre,omitempty"`
+	MplsOverGre          *MplsOverGreParameters `protobuf:"bytes,4,opt,name=mplsOverGre" json:"mplsOverGre,omitempty"`
+	Srv6                 *Srv6Parameters        `protobuf:"bytes,5,opt,name=srv6" json:"srv6,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *RemoteMechanism) Reset()         { *m = RemoteMechanism{} }
+func (m *RemoteMechanism) String() string { return proto.CompactTextString(m) }
+func (*RemoteMechanism) ProtoMessage()    {}
+func (*RemoteMechanism) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{16}
+}
+func (m *RemoteMechanism) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoteMechanism.Unmarshal(m, b)
+}
+func (m *RemoteMechanism) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoteMechanism.Marshal(b, m, deterministic)
+}
+func (dst *RemoteMechanism) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteMechanism.Merge(dst, src)
+}
+func (m *RemoteMechanism) XXX_Size() int {
+	return xxx_messageInfo_RemoteMechanism.Size(m)
+}
+func (m *RemoteMechanism) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteMechanism.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteMechanism proto.InternalMessageInfo
+
+func (m *RemoteMechanism) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RemoteMechanism) GetVxlan() *VxlanParameters {
+	if m != nil {
+		return m.Vxlan
+	}
+	return nil
+}
+
+func (m *RemoteMechanism) GetGre() *GreParameters {
+	if m != nil {
+		return m.Gre
+	}
+	return nil
+}
+
+func (m *RemoteMechanism) GetMplsOverGre() *MplsOverGreParameters {
+	if m != nil {
+		return m.MplsOverGre
+	}
+	return nil
+}
+
+func (m *RemoteMechanism) GetSrv6() *Srv6Parameters {
+	if m != nil {
+		return m.Srv6
+	}
+	return nil
+}
+
+// EndpointReference references a NetworkServiceEndpoint, possibly of another
+// namespace when selected through an imported service.
+type EndpointReference struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Uuid                 string   `protobuf:"bytes,3,opt,name=uuid" json:"uuid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndpointReference) Reset()         { *m = EndpointReference{} }
+func (m *EndpointReference) String() string { return proto.CompactTextString(m) }
+func (*EndpointReference) ProtoMessage()    {}
+func (*EndpointReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{17}
+}
+func (m *EndpointReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointReference.Unmarshal(m, b)
+}
+func (m *EndpointReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointReference.Marshal(b, m, deterministic)
+}
+func (dst *EndpointReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointReference.Merge(dst, src)
+}
+func (m *EndpointReference) XXX_Size() int {
+	return xxx_messageInfo_EndpointReference.Size(m)
+}
+func (m *EndpointReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointReference proto.InternalMessageInfo
+
+func (m *EndpointReference) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *EndpointReference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EndpointReference) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+// NetworkServiceConnection is a connection of a client pod to an endpoint of
+// a NetworkService, through one of the channels of the service.
+type NetworkServiceConnection struct {
+	Uuid string `protobuf:"bytes,1,opt,name=uuid" json:"uuid,omitempty"`
+	// client references the connected pod, in the namespace of the
+	// connection
+	Client *PodReference `protobuf:"bytes,2,opt,name=client" json:"client,omitempty"`
+	// networkService is the name of the service, in the namespace of the
+	// connection
+	NetworkService string `protobuf:"bytes,3,opt,name=networkService" json:"networkService,omitempty"`
+	// endpoint is the endpoint selected for the connection
+	Endpoint *EndpointReference `protobuf:"bytes,4,opt,name=endpoint" json:"endpoint,omitempty"`
+	// channel is the name of the channel of the service carrying the
+	// connection, empty for services without channels
+	Channel string `protobuf:"bytes,5,opt,name=channel" json:"channel,omitempty"`
+	// payload is the name of the payload requested by the client, empty
+	// when any payload is accepted
+	Payload string `protobuf:"bytes,6,opt,name=payload" json:"payload,omitempty"`
+	// mechanism plugs the connection into the client pod
+	Mechanism *LocalMechanism `protobuf:"bytes,7,opt,name=mechanism" json:"mechanism,omitempty"`
+	// remoteMechanism carries the connection between the nodes of the client
+	// and of the endpoint, unset when they run on the same node
+	RemoteMechanism      *RemoteMechanism `protobuf:"bytes,8,opt,name=remoteMechanism" json:"remoteMechanism,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NetworkServiceConnection) Reset()         { *m = NetworkServiceConnection{} }
+func (m *NetworkServiceConnection) String() string { return proto.CompactTextString(m) }
+func (*NetworkServiceConnection) ProtoMessage()    {}
+func (*NetworkServiceConnection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_netmesh_c650553582adbc10, []int{18}
+}
+func (m *NetworkServiceConnection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkServiceConnection.Unmarshal(m, b)
+}
+func (m *NetworkServiceConnection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkServiceConnection.Marshal(b, m, deterministic)
+}
+func (dst *NetworkServiceConnection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkServiceConnection.Merge(dst, src)
+}
+func (m *NetworkServiceConnection) XXX_Size() int {
+	return xxx_messageInfo_NetworkServiceConnection.Size(m)
+}
+func (m *NetworkServiceConnection) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkServiceConnection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkServiceConnection proto.InternalMessageInfo
+
+func (m *NetworkServiceConnection) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *NetworkServiceConnection) GetClient() *PodReference {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *NetworkServiceConnection) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *NetworkServiceConnection) GetEndpoint() *EndpointReference {
+	if m != nil {
+		return m.Endpoint
+	}
+	return nil
+}
+
+func (m *NetworkServiceConnection) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *NetworkServiceConnection) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *NetworkServiceConnection) GetMechanism() *LocalMechanism {
+	if m != nil {
+		return m.Mechanism
+	}
+	return nil
+}
+
+func (m *NetworkServiceConnection) GetRemoteMechanism() *RemoteMechanism {
+	if m != nil {
+		return m.RemoteMechanism
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*NetworkServiceEndpoint)(nil), "netmesh.NetworkServiceEndpoint")
+	proto.RegisterMapType((map[string]string)(nil), "netmesh.NetworkServiceEndpoint.LabelsEntry")
+	proto.RegisterType((*PodReference)(nil), "netmesh.PodReference")
+	proto.RegisterType((*LabelSelectorRequirement)(nil), "netmesh.LabelSelectorRequirement")
+	proto.RegisterType((*LabelSelector)(nil), "netmesh.LabelSelector")
+	proto.RegisterMapType((map[string]string)(nil), "netmesh.LabelSelector.MatchLabelsEntry")
+	proto.RegisterType((*NetworkService)(nil), "netmesh.NetworkService")
+	proto.RegisterType((*NetworkService_NetmeshChannel)(nil), "netmesh.NetworkService.NetmeshChannel")
+	proto.RegisterType((*ExportPolicy)(nil), "netmesh.ExportPolicy")
+	proto.RegisterType((*ServiceReference)(nil), "netmesh.ServiceReference")
+	proto.RegisterType((*KernelInterfaceParameters)(nil), "netmesh.KernelInterfaceParameters")
+	proto.RegisterType((*MemifParameters)(nil), "netmesh.MemifParameters")
+	proto.RegisterType((*VhostUserParameters)(nil), "netmesh.VhostUserParameters")
+	proto.RegisterType((*SriovVfParameters)(nil), "netmesh.SriovVfParameters")
+	proto.RegisterType((*VxlanParameters)(nil), "netmesh.VxlanParameters")
+	proto.RegisterType((*GreParameters)(nil), "netmesh.GreParameters")
+	proto.RegisterType((*MplsOverGreParameters)(nil), "netmesh.MplsOverGreParameters")
+	proto.RegisterType((*Srv6Parameters)(nil), "netmesh.Srv6Parameters")
+	proto.RegisterType((*LocalMechanism)(nil), "netmesh.LocalMechanism")
+	proto.RegisterType((*RemoteMechanism)(nil), "netmesh.RemoteMechanism")
+	proto.RegisterType((*EndpointReference)(nil), "netmesh.EndpointReference")
+	proto.RegisterType((*NetworkServiceConnection)(nil), "netmesh.NetworkServiceConnection")
+	proto.RegisterEnum("netmesh.Payload", Payload_name, Payload_value)
+	proto.RegisterEnum("netmesh.LocalMechanismType", LocalMechanismType_name, LocalMechanismType_value)
+	proto.RegisterEnum("netmesh.RemoteMechanismType", RemoteMechanismType_name, RemoteMechanismType_value)
+}
+
+func init() { proto.RegisterFile("netmesh.proto", fileDescriptor_netmesh_c650553582adbc10) }
+
+var fileDescriptor_netmesh_c650553582adbc10 = []byte{
+	// 1331 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x6f, 0x6f, 0xdb, 0x44,
+	0x18, 0x27, 0x76, 0xd2, 0x38, 0x4f, 0x96, 0xd6, 0xbd, 0x75, 0x9b, 0x17, 0xa6, 0x51, 0xfc, 0x62,
+	0xab, 0x3a, 0x11, 0x89, 0x6e, 0x54, 0x30, 0x21, 0x44, 0x96, 0xba, 0x5b, 0x58, 0xfe, 0x71, 0xc9,
+	0x22, 0x26, 0x5e, 0x04, 0xcf, 0xb9, 0x52, 0xab, 0x8e, 0x6d, 0xce, 0xd7, 0xd0, 0x7c, 0x0f, 0x3e,
+	0x07, 0xdf, 0x0b, 0x09, 0xf1, 0x0d, 0x90, 0xd0, 0x9d, 0x2f, 0xfe, 0xd7, 0x14, 0x8d, 0xf1, 0xee,
+	0x9e, 0x27, 0xcf, 0xdf, 0xdf, 0xfd, 0x9e, 0xe7, 0x1c, 0x68, 0xf8, 0x84, 0x2d, 0x48, 0x74, 0xde,
+	0x0a, 0x69, 0xc0, 0x02, 0x54, 0x95, 0xa2, 0xf9, 0xb7, 0x02, 0x77, 0x07, 0x84, 0xfd, 0x1a, 0xd0,
+	0x8b, 0x31, 0xa1, 0x4b, 0xd7, 0x21, 0x96, 0x3f, 0x0f, 0x03, 0xd7, 0x67, 0x08, 0x41, 0xd9, 0xb7,
+	0x17, 0xc4, 0x28, 0xed, 0x97, 0x0e, 0x6a, 0x58, 0x9c, 0xb9, 0xee, 0xf2, 0xd2, 0x9d, 0x1b, 0x4a,
+	0xac, 0xe3, 0x67, 0x61, 0x17, 0xcc, 0x89, 0xa1, 0x4a, 0xbb, 0x60, 0x4e, 0xd0, 0x63, 0x50, 0xc3,
+	0x60, 0x6e, 0x94, 0xf7, 0x4b, 0x07, 0xf5, 0xa3, 0x3b, 0xad, 0x75, 0xf2, 0x51, 0x30, 0xc7, 0xe4,
+	0x8c, 0x50, 0xe2, 0x3b, 0x04, 0x73, 0x0b, 0x74, 0x17, 0xb6, 0xa2, 0xc0, 0xb9, 0x20, 0xcc, 0xa8,
+	0x08, 0x77, 0x29, 0xa1, 0x0e, 0x6c, 0x79, 0xf6, 0x3b, 0xe2, 0x45, 0xc6, 0xd6, 0xbe, 0x7a, 0x50,
+	0x3f, 0x7a, 0x92, 0xc4, 0xd8, 0x5c, 0x6d, 0xab, 0x27, 0xac, 0x2d, 0x9f, 0xd1, 0x15, 0x96, 0xae,
+	0xe8, 0x21, 0xc0, 0x82, 0x38, 0xe7, 0xb6, 0xef, 0x46, 0x8b, 0xc8, 0xa8, 0xee, 0xab, 0x07, 0x35,
+	0x9c, 0xd1, 0xa0, 0x26, 0x68, 0x8e, 0x1d, 0xda, 0x8e, 0xcb, 0x56, 0x86, 0xb6, 0x5f, 0x3a, 0x68,
+	0xe0, 0x44, 0x46, 0x87, 0xa0, 0x53, 0xb2, 0x08, 0x18, 0xe9, 0xa7, 0x11, 0x6a, 0x22, 0xc2, 0x35,
+	0x7d, 0xf3, 0x2b, 0xa8, 0x67, 0xd2, 0x23, 0x1d, 0xd4, 0x0b, 0xb2, 0x92, 0xb8, 0xf1, 0x23, 0xda,
+	0x83, 0xca, 0xd2, 0xf6, 0x2e, 0x89, 0xc4, 0x2d, 0x16, 0x9e, 0x2b, 0x5f, 0x96, 0xcc, 0x67, 0x70,
+	0x2b, 0x0b, 0xca, 0x46, 0xd0, 0x75, 0x50, 0x53, 0xcc, 0xf9, 0xd1, 0xfc, 0x09, 0x0c, 0x91, 0x70,
+	0x4c, 0x3c, 0xe2, 0xb0, 0x80, 0x62, 0xf2, 0xcb, 0xa5, 0x4b, 0xc9, 0x82, 0xf8, 0x6c, 0x43, 0xf6,
+	0x26, 0x68, 0x41, 0x48, 0xa8, 0xcd, 0x02, 0x2a, 0x83, 0x24, 0x32, 0xc7, 0x5f, 0x14, 0x13, 0x19,
+	0xaa, 0x68, 0x4e, 0x4a, 0xe6, 0x1f, 0x25, 0x68, 0xe4, 0x52, 0xa0, 0x2e, 0xd4, 0x17, 0x36, 0x73,
+	0xce, 0xe3, 0x4e, 0x8d, 0x92, 0xb8, 0x96, 0xc7, 0xc9, 0xb5, 0xe4, 0x8c, 0x5b, 0xfd, 0xd4, 0x32,
+	0xbe, 0x92, 0xac, 0x2f, 0xea, 0x83, 0x2e, 0x44, 0xeb, 0x2a, 0xa4, 0x24, 0x8a, 0xdc, 0xc0, 0x8f,
+	0x0c, 0x45, 0xc4, 0xfb, 0x74, 0x73, 0xbc, 0x4c, 0x7f, 0xf8, 0x9a, 0x6b, 0xf3, 0x1b, 0xd0, 0x8b,
+	0xf9, 0xfe, 0xd3, 0x1d, 0xfc, 0xae, 0xc2, 0x76, 0x9e, 0x55, 0xef, 0xcd, 0xfd, 0x26, 0x68, 0x91,
+	0xac, 0x51, 0xf2, 0x3f, 0x91, 0xd1, 0x0b, 0xd0, 0x38, 0x43, 0x7c, 0x8e, 0x56, 0x59, 0x74, 0xf7,
+	0xe8, 0x06, 0x12, 0xb7, 0x06, 0xb1, 0xba, 0x13, 0x9b, 0xe3, 0xc4, 0x0f, 0x7d, 0x0d, 0x0d, 0x2f,
+	0x0b, 0x84, 0x98, 0x92, 0xfa, 0xd1, 0xdd, 0x1b, 0x60, 0xca, 0x1b, 0xa3, 0xcf, 0x41, 0x23, 0x57,
+	0x61, 0x40, 0xd9, 0x24, 0x30, 0xb6, 0x0a, 0xa3, 0x68, 0x89, 0x1f, 0x46, 0x81, 0xe7, 0x3a, 0x2b,
+	0x9c, 0x98, 0xa1, 0xa7, 0x50, 0x75, 0x17, 0xfc, 0x1c, 0xcf, 0x4b, 0xfd, 0xe8, 0x7e, 0xe2, 0x21,
+	0x8b, 0x4d, 0x07, 0x78, 0x6d, 0xc9, 0x51, 0x08, 0x29, 0x39, 0x73, 0xaf, 0x48, 0x64, 0x68, 0x82,
+	0x46, 0x89, 0xdc, 0xc4, 0x02, 0xdb, 0x4c, 0x77, 0x1b, 0xb1, 0x35, 0xa0, 0x1a, 0xda, 0x2b, 0x2f,
+	0xb0, 0xd7, 0xf0, 0xae, 0x45, 0x6e, 0xbd, 0xf4, 0x6c, 0x5f, 0xa0, 0xdb, 0xc0, 0xe2, 0x6c, 0x32,
+	0xb8, 0x95, 0x2d, 0x9f, 0xcf, 0x39, 0x8f, 0x12, 0x85, 0xb6, 0x43, 0x62, 0x66, 0xd6, 0x70, 0x46,
+	0x83, 0x4e, 0x60, 0x37, 0x91, 0x12, 0x24, 0x95, 0x7f, 0x45, 0xf2, 0xba, 0x83, 0x79, 0x02, 0x7a,
+	0x11, 0x02, 0xf4, 0x00, 0x6a, 0x89, 0xa1, 0x6c, 0x28, 0x55, 0x24, 0x9d, 0x2a, 0x69, 0xa7, 0xa6,
+	0x05, 0xf7, 0x5f, 0x13, 0xea, 0x13, 0xaf, 0xeb, 0x33, 0x42, 0xcf, 0x6c, 0x87, 0x8c, 0x6c, 0x6a,
+	0x2f, 0x08, 0x23, 0x34, 0xda, 0x08, 0xcd, 0x1e, 0x54, 0x7c, 0xc2, 0xc4, 0x84, 0x08, 0xde, 0x0a,
+	0xc1, 0xfc, 0x1e, 0x76, 0xfa, 0x64, 0xe1, 0x9e, 0x65, 0x9c, 0xd3, 0x55, 0x5a, 0xca, 0xad, 0xd2,
+	0x6d, 0x50, 0x24, 0x6b, 0x1b, 0x58, 0x71, 0xc5, 0xca, 0x5d, 0xd8, 0x11, 0x23, 0x31, 0x63, 0x35,
+	0x2c, 0x25, 0xd3, 0x82, 0xdb, 0xd3, 0xf3, 0x20, 0x62, 0x6f, 0x22, 0x42, 0xdf, 0x23, 0x2c, 0xd7,
+	0x13, 0xba, 0x24, 0x31, 0x92, 0x1a, 0x96, 0x92, 0xb9, 0x82, 0xdd, 0x31, 0x75, 0x83, 0xe5, 0xb4,
+	0x50, 0x5b, 0x78, 0x36, 0x48, 0x5b, 0x93, 0x12, 0xbf, 0xf7, 0xe5, 0x59, 0xd7, 0x9f, 0x93, 0x2b,
+	0x59, 0xe0, 0x5a, 0xe4, 0x77, 0x1a, 0x3a, 0x6e, 0x7b, 0x3e, 0xe7, 0x63, 0x2e, 0x67, 0x2b, 0xa3,
+	0x49, 0x78, 0x51, 0xce, 0xf0, 0xc2, 0x85, 0x9d, 0xe9, 0x95, 0x67, 0xfb, 0x99, 0xc4, 0x7b, 0x50,
+	0x89, 0xa8, 0xd3, 0x0d, 0x65, 0xde, 0x58, 0xe0, 0xda, 0x79, 0xc4, 0xba, 0xe1, 0x1a, 0x53, 0x21,
+	0xf0, 0x9d, 0xb1, 0xf4, 0x5d, 0xc9, 0x34, 0x7e, 0xe4, 0xe5, 0xcd, 0x23, 0x36, 0x0a, 0x28, 0x93,
+	0x79, 0xd6, 0xa2, 0xd9, 0x87, 0xc6, 0x4b, 0x4a, 0x3e, 0x3c, 0x11, 0x5f, 0x4e, 0x32, 0xd1, 0x05,
+	0x59, 0x99, 0x6f, 0xe1, 0x4e, 0x3f, 0xf4, 0xa2, 0xe1, 0x92, 0xd0, 0x0f, 0x0f, 0xbb, 0x07, 0x15,
+	0x31, 0xff, 0x32, 0x70, 0x2c, 0x98, 0xdf, 0xc1, 0xf6, 0x98, 0x2e, 0x8f, 0x33, 0x31, 0x9b, 0xa0,
+	0x79, 0x81, 0x63, 0x7b, 0x63, 0x77, 0x2e, 0xc3, 0x26, 0x32, 0x27, 0x74, 0xfc, 0xbc, 0x8d, 0x93,
+	0x4d, 0x97, 0x2a, 0xcc, 0xdf, 0x14, 0xd8, 0xee, 0x71, 0xd3, 0xe4, 0xf1, 0xe3, 0xf7, 0xc0, 0x56,
+	0x61, 0x42, 0x59, 0x7e, 0x46, 0x3d, 0xd8, 0xb9, 0xc8, 0x73, 0x5c, 0x4e, 0x9b, 0x99, 0x4c, 0xdb,
+	0x8d, 0x33, 0x80, 0x8b, 0xae, 0xa8, 0x05, 0x95, 0x05, 0xa7, 0xba, 0x68, 0xab, 0x7e, 0x64, 0x24,
+	0x31, 0x0a, 0x03, 0x80, 0x63, 0x33, 0xf4, 0x1c, 0x6a, 0xcb, 0x35, 0x8f, 0xe5, 0x17, 0xc8, 0x83,
+	0xc4, 0x67, 0x03, 0xc3, 0x71, 0x6a, 0x8e, 0x9e, 0x41, 0x35, 0x8a, 0xc9, 0x2b, 0x37, 0x6d, 0x33,
+	0x5d, 0x7f, 0x45, 0x52, 0xe3, 0xb5, 0xa9, 0xf9, 0x57, 0x09, 0x76, 0x70, 0xfe, 0xa3, 0x60, 0x23,
+	0x2e, 0x2d, 0xa8, 0x2c, 0x39, 0x3f, 0x0d, 0xa5, 0xd0, 0x49, 0x81, 0xb5, 0x38, 0x36, 0x43, 0x07,
+	0xa0, 0xfe, 0x4c, 0x89, 0xa1, 0x16, 0x36, 0x55, 0x8e, 0x21, 0x98, 0x9b, 0xa0, 0x6f, 0xa1, 0xbe,
+	0x48, 0xf9, 0x23, 0xbb, 0x7e, 0x98, 0x22, 0xb5, 0x89, 0x5b, 0x38, 0xeb, 0x82, 0x9e, 0x40, 0x39,
+	0xa2, 0xcb, 0x63, 0xd9, 0xf6, 0xbd, 0x4c, 0xdb, 0x59, 0xee, 0x60, 0x61, 0x64, 0xbe, 0x85, 0xdd,
+	0xf5, 0x87, 0xd7, 0xff, 0xd8, 0x85, 0xc9, 0x8b, 0xaa, 0xa6, 0x2f, 0xaa, 0xf9, 0xa7, 0x02, 0x46,
+	0xfe, 0x75, 0xec, 0x04, 0xbe, 0x4f, 0x1c, 0xe6, 0x06, 0x7e, 0xe2, 0x50, 0x4a, 0x1d, 0xd0, 0x67,
+	0xb0, 0xe5, 0x78, 0x2e, 0xf1, 0x99, 0xa1, 0x14, 0x9e, 0xb8, 0xdc, 0xd7, 0xa6, 0x34, 0x42, 0x8f,
+	0x60, 0xdb, 0xcf, 0x85, 0x97, 0xd9, 0x0b, 0x5a, 0x74, 0x0c, 0x1a, 0x91, 0x2d, 0x1a, 0xe5, 0x02,
+	0x15, 0xae, 0xf5, 0x8e, 0x13, 0x5b, 0xbe, 0x32, 0xe4, 0xeb, 0x2d, 0xbf, 0x68, 0xd7, 0x62, 0xf6,
+	0x8d, 0xdb, 0xca, 0xbf, 0x71, 0x5f, 0x40, 0x2d, 0xf9, 0x2a, 0x35, 0xaa, 0x85, 0x0b, 0xc8, 0xcf,
+	0x1b, 0x4e, 0x2d, 0xd1, 0x0b, 0xd8, 0x29, 0x7c, 0x8a, 0x1a, 0x5a, 0x81, 0x58, 0x05, 0x56, 0xe2,
+	0xa2, 0xc3, 0xe1, 0x8f, 0x50, 0x1d, 0xc9, 0x2a, 0xee, 0xc1, 0xed, 0x51, 0xfb, 0x6d, 0x6f, 0xd8,
+	0x3e, 0x99, 0xbd, 0x19, 0x8c, 0x47, 0x56, 0xa7, 0x7b, 0xda, 0xb5, 0x4e, 0xf4, 0x8f, 0xd0, 0x2d,
+	0xd0, 0xac, 0xc9, 0x2b, 0x0b, 0x0f, 0xac, 0x89, 0x5e, 0x42, 0x1a, 0x94, 0xbb, 0xa3, 0xe9, 0x33,
+	0x5d, 0x91, 0xa7, 0x63, 0x5d, 0xe5, 0xa7, 0xfe, 0xa8, 0x37, 0xd6, 0xcb, 0xa8, 0x0e, 0xd5, 0xde,
+	0xd1, 0x6c, 0xda, 0x6b, 0x0f, 0xf4, 0xca, 0x21, 0x03, 0x94, 0xaf, 0x7e, 0xc2, 0xa7, 0xe0, 0x13,
+	0xf8, 0xb8, 0x37, 0xec, 0xb4, 0x7b, 0xb3, 0xbe, 0xd5, 0x79, 0xd5, 0x1e, 0x74, 0xc7, 0xfd, 0x42,
+	0xbe, 0x3d, 0xd0, 0x5f, 0xf3, 0x6c, 0xbd, 0x59, 0x77, 0x30, 0xb1, 0xf0, 0x69, 0xbb, 0x63, 0xe9,
+	0x25, 0x54, 0x83, 0x4a, 0xdf, 0xea, 0x77, 0x4f, 0x75, 0x05, 0x6d, 0x03, 0x4c, 0x5f, 0x0d, 0xc7,
+	0x93, 0xd9, 0x9b, 0xb1, 0x85, 0x75, 0x95, 0x17, 0x38, 0xc6, 0xdd, 0xe1, 0x74, 0x36, 0x3d, 0xd5,
+	0xcb, 0x87, 0xe7, 0x70, 0xbb, 0xd0, 0xb6, 0x48, 0xbb, 0x0f, 0x0f, 0xb0, 0xd5, 0x1f, 0x4e, 0xac,
+	0x1b, 0xf3, 0xd6, 0xa0, 0x32, 0xfd, 0x81, 0x57, 0x5e, 0x42, 0x55, 0x50, 0x5f, 0x62, 0x4b, 0x57,
+	0xd0, 0x2e, 0x34, 0x78, 0x67, 0xb3, 0xe1, 0xd4, 0xc2, 0x33, 0xae, 0x12, 0xcd, 0x8e, 0xf1, 0xf4,
+	0x58, 0x2f, 0xbf, 0xdb, 0x12, 0x7f, 0xa6, 0x9e, 0xfe, 0x33, 0x00, 0x21, 0x33, 0x0a, 0xba, 0x5d,
+	0x0d, 0x00, 0x00,
 }
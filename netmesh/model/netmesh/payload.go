@@ -0,0 +1,174 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmesh
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the payloads, as written in the payload of channels
+const (
+	PayloadNameEthernet = "ethernet"
+	PayloadNameIPv4     = "ipv4"
+	PayloadNameIPv6     = "ipv6"
+	PayloadNameMPLS     = "mpls"
+	PayloadNameL2VLAN   = "l2-vlan"
+)
+
+// payloadNames maps the payloads to their names, PAYLOAD_UNSPECIFIED has none
+var payloadNames = map[Payload]string{
+	Payload_ETHERNET: PayloadNameEthernet,
+	Payload_IPV4:     PayloadNameIPv4,
+	Payload_IPV6:     PayloadNameIPv6,
+	Payload_MPLS:     PayloadNameMPLS,
+	Payload_L2_VLAN:  PayloadNameL2VLAN,
+}
+
+// MaxVLAN is the highest valid VLAN ID, 0 and 4095 are reserved
+const MaxVLAN = 4094
+
+// PayloadNames returns the names of all the payloads, in the order of the
+// enumeration.
+func PayloadNames() []string {
+	names := make([]string, 0, len(payloadNames))
+	for i := int32(1); i < int32(len(Payload_name)); i++ {
+		names = append(names, payloadNames[Payload(i)])
+	}
+	return names
+}
+
+// Name returns the name of the payload as written in channels, or an empty
+// string for PAYLOAD_UNSPECIFIED.
+func (x Payload) Name() string {
+	return payloadNames[x]
+}
+
+// ParsePayload returns the payload with the given name. The empty name stands
+// for PAYLOAD_UNSPECIFIED.
+func ParsePayload(name string) (Payload, error) {
+	if name == "" {
+		return Payload_PAYLOAD_UNSPECIFIED, nil
+	}
+	for payload, payloadName := range payloadNames {
+		if name == payloadName {
+			return payload, nil
+		}
+	}
+	return Payload_PAYLOAD_UNSPECIFIED, fmt.Errorf("unknown payload %q, expected one of %s",
+		name, strings.Join(PayloadNames(), ", "))
+}
+
+// PayloadType returns the payload carried by the channel.
+func (m *NetworkService_NetmeshChannel) PayloadType() (Payload, error) {
+	return ParsePayload(m.Payload)
+}
+
+// Validate checks the payload of the channel and its VLAN ID, which must be
+// set for the L2_VLAN payload only.
+func (m *NetworkService_NetmeshChannel) Validate() error {
+	payload, err := m.PayloadType()
+	if err != nil {
+		return err
+	}
+	if payload == Payload_PAYLOAD_UNSPECIFIED {
+		return fmt.Errorf("payload is required")
+	}
+	if payload == Payload_L2_VLAN {
+		if m.Vlan == 0 || m.Vlan > MaxVLAN {
+			return fmt.Errorf("vlan must be between 1 and %d for payload %s, got %d", MaxVLAN, PayloadNameL2VLAN, m.Vlan)
+		}
+	} else if m.Vlan != 0 {
+		return fmt.Errorf("vlan is only allowed for payload %s", PayloadNameL2VLAN)
+	}
+	return nil
+}
+
+// PayloadAdaptation describes how a payload requested by a client is carried
+// by a channel of another payload.
+type PayloadAdaptation string
+
+// Adaptations of payloads
+const (
+	// PayloadAdaptationNone means the channel carries the requested payload
+	// as is
+	PayloadAdaptationNone PayloadAdaptation = ""
+	// PayloadAdaptationFraming means the packets of an L3 payload are framed
+	// into the frames of an L2 channel at the ends of the connection
+	PayloadAdaptationFraming PayloadAdaptation = "framing"
+	// PayloadAdaptationVLANTagging means Ethernet frames are tagged with the
+	// VLAN ID of the channel, or untagged, at the ends of the connection
+	PayloadAdaptationVLANTagging PayloadAdaptation = "vlan-tagging"
+)
+
+// PayloadMismatchError is returned when a channel cannot carry the payload
+// requested by a client.
+// +k8s:deepcopy-gen=false
+type PayloadMismatchError struct {
+	Requested Payload
+	Offered   Payload
+}
+
+// Error implements the error interface.
+func (e *PayloadMismatchError) Error() string {
+	return fmt.Sprintf("payload %s cannot be carried by a channel of payload %s",
+		payloadString(e.Requested), payloadString(e.Offered))
+}
+
+// payloadString returns the name of a payload, or its enumeration name if it
+// has none.
+func payloadString(payload Payload) string {
+	if name := payload.Name(); name != "" {
+		return name
+	}
+	return payload.String()
+}
+
+// isL2 returns true for the payloads made of L2 frames.
+func isL2(payload Payload) bool {
+	return payload == Payload_ETHERNET || payload == Payload_L2_VLAN
+}
+
+// AdaptPayload returns how the payload requested by a client is carried by a
+// channel of the offered payload. L3 payloads are framed into L2 channels, and
+// Ethernet frames are tagged into VLAN channels and conversely. L2 payloads
+// cannot be carried by L3 channels, nor L3 payloads by channels of another L3
+// payload, in which case a *PayloadMismatchError is returned. A client which
+// did not specify a payload accepts any.
+func AdaptPayload(requested, offered Payload) (PayloadAdaptation, error) {
+	switch {
+	case requested == Payload_PAYLOAD_UNSPECIFIED || requested == offered:
+		return PayloadAdaptationNone, nil
+	case isL2(requested) && isL2(offered):
+		return PayloadAdaptationVLANTagging, nil
+	case !isL2(requested) && isL2(offered):
+		return PayloadAdaptationFraming, nil
+	}
+	return PayloadAdaptationNone, &PayloadMismatchError{Requested: requested, Offered: offered}
+}
+
+// Adapt returns how the payload requested by a client is carried by the
+// channel, see AdaptPayload.
+func (m *NetworkService_NetmeshChannel) Adapt(requested Payload) (PayloadAdaptation, error) {
+	offered, err := m.PayloadType()
+	if err != nil {
+		return PayloadAdaptationNone, fmt.Errorf("channel %s: %s", m.Name, err)
+	}
+	adaptation, err := AdaptPayload(requested, offered)
+	if err != nil {
+		return PayloadAdaptationNone, fmt.Errorf("channel %s: %s", m.Name, err)
+	}
+	return adaptation, nil
+}
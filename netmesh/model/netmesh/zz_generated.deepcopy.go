@@ -18,6 +18,278 @@
 
 package netmesh
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointReference) DeepCopyInto(out *EndpointReference) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointReference.
+func (in *EndpointReference) DeepCopy() *EndpointReference {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportPolicy) DeepCopyInto(out *ExportPolicy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportPolicy.
+func (in *ExportPolicy) DeepCopy() *ExportPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GreParameters) DeepCopyInto(out *GreParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GreParameters.
+func (in *GreParameters) DeepCopy() *GreParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GreParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelInterfaceParameters) DeepCopyInto(out *KernelInterfaceParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelInterfaceParameters.
+func (in *KernelInterfaceParameters) DeepCopy() *KernelInterfaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelInterfaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelSelector) DeepCopyInto(out *LabelSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]*LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			if (*in)[i] == nil {
+				(*out)[i] = nil
+			} else {
+				(*out)[i] = new(LabelSelectorRequirement)
+				(*in)[i].DeepCopyInto((*out)[i])
+			}
+		}
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelSelector.
+func (in *LabelSelector) DeepCopy() *LabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelSelectorRequirement) DeepCopyInto(out *LabelSelectorRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelSelectorRequirement.
+func (in *LabelSelectorRequirement) DeepCopy() *LabelSelectorRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelSelectorRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalMechanism) DeepCopyInto(out *LocalMechanism) {
+	*out = *in
+	if in.KernelInterface != nil {
+		in, out := &in.KernelInterface, &out.KernelInterface
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(KernelInterfaceParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Memif != nil {
+		in, out := &in.Memif, &out.Memif
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MemifParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.VhostUser != nil {
+		in, out := &in.VhostUser, &out.VhostUser
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(VhostUserParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.SriovVf != nil {
+		in, out := &in.SriovVf, &out.SriovVf
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SriovVfParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalMechanism.
+func (in *LocalMechanism) DeepCopy() *LocalMechanism {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalMechanism)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemifParameters) DeepCopyInto(out *MemifParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemifParameters.
+func (in *MemifParameters) DeepCopy() *MemifParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MemifParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MplsOverGreParameters) DeepCopyInto(out *MplsOverGreParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MplsOverGreParameters.
+func (in *MplsOverGreParameters) DeepCopy() *MplsOverGreParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MplsOverGreParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkService) DeepCopyInto(out *NetworkService) {
 	*out = *in
@@ -33,6 +305,41 @@ func (in *NetworkService) DeepCopyInto(out *NetworkService) {
 			}
 		}
 	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ExportTo != nil {
+		in, out := &in.ExportTo, &out.ExportTo
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ExportPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]*ServiceReference, len(*in))
+		for i := range *in {
+			if (*in)[i] == nil {
+				(*out)[i] = nil
+			} else {
+				(*out)[i] = new(ServiceReference)
+				(*in)[i].DeepCopyInto((*out)[i])
+			}
+		}
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
 	if in.XXX_unrecognized != nil {
 		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
@@ -52,9 +359,93 @@ func (in *NetworkService) DeepCopy() *NetworkService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkServiceConnection) DeepCopyInto(out *NetworkServiceConnection) {
+	*out = *in
+	if in.Client != nil {
+		in, out := &in.Client, &out.Client
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodReference)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(EndpointReference)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Mechanism != nil {
+		in, out := &in.Mechanism, &out.Mechanism
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalMechanism)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RemoteMechanism != nil {
+		in, out := &in.RemoteMechanism, &out.RemoteMechanism
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RemoteMechanism)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkServiceConnection.
+func (in *NetworkServiceConnection) DeepCopy() *NetworkServiceConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkServiceConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkServiceEndpoint) DeepCopyInto(out *NetworkServiceEndpoint) {
 	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodReference)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Mechanisms != nil {
+		in, out := &in.Mechanisms, &out.Mechanisms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoteMechanisms != nil {
+		in, out := &in.RemoteMechanisms, &out.RemoteMechanisms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
 	if in.XXX_unrecognized != nil {
 		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
@@ -95,3 +486,193 @@ func (in *NetworkService_NetmeshChannel) DeepCopy() *NetworkService_NetmeshChann
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodReference) DeepCopyInto(out *PodReference) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodReference.
+func (in *PodReference) DeepCopy() *PodReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PodReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteMechanism) DeepCopyInto(out *RemoteMechanism) {
+	*out = *in
+	if in.Vxlan != nil {
+		in, out := &in.Vxlan, &out.Vxlan
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(VxlanParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Gre != nil {
+		in, out := &in.Gre, &out.Gre
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(GreParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.MplsOverGre != nil {
+		in, out := &in.MplsOverGre, &out.MplsOverGre
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MplsOverGreParameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Srv6 != nil {
+		in, out := &in.Srv6, &out.Srv6
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Srv6Parameters)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMechanism.
+func (in *RemoteMechanism) DeepCopy() *RemoteMechanism {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteMechanism)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SriovVfParameters) DeepCopyInto(out *SriovVfParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovVfParameters.
+func (in *SriovVfParameters) DeepCopy() *SriovVfParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SriovVfParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Srv6Parameters) DeepCopyInto(out *Srv6Parameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Srv6Parameters.
+func (in *Srv6Parameters) DeepCopy() *Srv6Parameters {
+	if in == nil {
+		return nil
+	}
+	out := new(Srv6Parameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VhostUserParameters) DeepCopyInto(out *VhostUserParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VhostUserParameters.
+func (in *VhostUserParameters) DeepCopy() *VhostUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(VhostUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VxlanParameters) DeepCopyInto(out *VxlanParameters) {
+	*out = *in
+	out.XXX_NoUnkeyedLiteral = in.XXX_NoUnkeyedLiteral
+	if in.XXX_unrecognized != nil {
+		in, out := &in.XXX_unrecognized, &out.XXX_unrecognized
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VxlanParameters.
+func (in *VxlanParameters) DeepCopy() *VxlanParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(VxlanParameters)
+	in.DeepCopyInto(out)
+	return out
+}
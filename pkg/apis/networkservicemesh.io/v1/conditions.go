@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetCondition returns the condition of the given type, or nil if the list
+// does not contain it.
+func GetCondition(conditions []Condition, conditionType ConditionType) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the condition of the given type is present
+// and has status True.
+func IsConditionTrue(conditions []Condition, conditionType ConditionType) bool {
+	condition := GetCondition(conditions, conditionType)
+	return condition != nil && condition.Status == ConditionTrue
+}
+
+// SetCondition adds the condition to the list, or replaces the condition of
+// the same type. The last transition time is kept when the status does not
+// change, and set to now when it does and the condition does not carry one.
+// The list passed in is not modified, an updated copy is returned.
+func SetCondition(conditions []Condition, condition Condition) []Condition {
+	result := append([]Condition(nil), conditions...)
+
+	existing := GetCondition(result, condition.Type)
+	if existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = meta.Now()
+	}
+
+	if existing != nil {
+		*existing = condition
+		return result
+	}
+	return append(result, condition)
+}
+
+// RemoveCondition removes the condition of the given type from the list. The
+// list passed in is not modified, an updated copy is returned.
+func RemoveCondition(conditions []Condition, conditionType ConditionType) []Condition {
+	var result []Condition
+	for _, existing := range conditions {
+		if existing.Type != conditionType {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
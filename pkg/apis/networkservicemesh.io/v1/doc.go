@@ -16,6 +16,7 @@
 
 // +k8s:deepcopy-gen=package
 // +k8s:defaulter-gen=TypeMeta
-// +groupName=networkservice.mesh
+// +groupName=networkservicemesh.io
+// +groupGoName=Networkservice
 
 package v1
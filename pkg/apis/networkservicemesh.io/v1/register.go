@@ -55,6 +55,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NetworkServiceChannelList{},
 		&NetworkServiceEndpoint{},
 		&NetworkServiceEndpointList{},
+		&NetworkServiceConnection{},
+		&NetworkServiceConnectionList{},
 	)
 
 	scheme.AddKnownTypes(SchemeGroupVersion,
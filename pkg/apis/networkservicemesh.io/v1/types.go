@@ -22,16 +22,229 @@ import (
 
 // Constants to register CRDs for our resources
 const (
-	NSMGroup           string = "networkservicemesh.io"
-	NSMGroupVersion    string = "v1"
-	NSMEPPlural        string = "networkserviceendpoints"
-	FullNSMEPName      string = NSMEPPlural + "." + NSMGroup
-	NSMChannelPlural   string = "networkservicechannels"
-	FullNSMChannelName string = NSMChannelPlural + "." + NSMGroup
-	NSMPlural          string = "networkservices"
-	FullNSMName        string = NSMPlural + "." + NSMGroup
+	NSMGroup              string = "networkservicemesh.io"
+	NSMGroupVersion       string = "v1"
+	NSMEPPlural           string = "networkserviceendpoints"
+	FullNSMEPName         string = NSMEPPlural + "." + NSMGroup
+	NSMChannelPlural      string = "networkservicechannels"
+	FullNSMChannelName    string = NSMChannelPlural + "." + NSMGroup
+	NSMPlural             string = "networkservices"
+	FullNSMName           string = NSMPlural + "." + NSMGroup
+	NSMConnectionPlural   string = "networkserviceconnections"
+	FullNSMConnectionName string = NSMConnectionPlural + "." + NSMGroup
 )
 
+// Short names and category of the CRDs, as used by kubectl. NetworkServices
+// cannot use "ns", which is the short name of Namespaces.
+const (
+	NSMCategory            string = "nsm"
+	NSMEPShortName         string = "nse"
+	NSMChannelShortName    string = "nsc"
+	NSMShortName           string = "nsvc"
+	NSMConnectionShortName string = "nscn"
+)
+
+// PrinterColumn describes an additional column shown by kubectl get for a
+// CRD. JSONPath is evaluated against the objects of every served version.
+// +k8s:deepcopy-gen=false
+type PrinterColumn struct {
+	Name        string
+	Type        string
+	JSONPath    string
+	Description string
+	// Priority 0 columns are always shown, others only in wide output
+	Priority int32
+}
+
+// ageColumn is the column kubectl shows by default, it has to be listed
+// explicitly once other columns are defined
+var ageColumn = PrinterColumn{
+	Name:     "Age",
+	Type:     "date",
+	JSONPath: ".metadata.creationTimestamp",
+}
+
+// NetworkServiceEndpointColumns are the printer columns of
+// NetworkServiceEndpoints
+var NetworkServiceEndpointColumns = []PrinterColumn{
+	{Name: "State", Type: "string", JSONPath: ".status.state", Description: "State of the endpoint"},
+	{Name: "Node", Type: "string", JSONPath: ".spec.node", Description: "Node the endpoint runs on"},
+	{Name: "UUID", Type: "string", JSONPath: ".spec.uuid", Description: "UUID of the endpoint", Priority: 1},
+	{Name: "Heartbeat", Type: "date", JSONPath: ".status.lastHeartbeatTime", Description: "Last heartbeat of the endpoint", Priority: 1},
+	ageColumn,
+}
+
+// NetworkServiceChannelColumns are the printer columns of
+// NetworkServiceChannels
+var NetworkServiceChannelColumns = []PrinterColumn{
+	{Name: "Payload", Type: "string", JSONPath: ".spec.payload", Description: "Payload carried by the channel"},
+	{Name: "State", Type: "string", JSONPath: ".status.state", Description: "State of the channel"},
+	ageColumn,
+}
+
+// NetworkServiceColumns are the printer columns of NetworkServices
+var NetworkServiceColumns = []PrinterColumn{
+	{Name: "Selector", Type: "string", JSONPath: ".spec.selector", Description: "Selector of the endpoints of the service"},
+	{Name: "Channels", Type: "integer", JSONPath: ".status.channelCount", Description: "Number of channels of the service"},
+	{Name: "State", Type: "string", JSONPath: ".status.state", Description: "State of the service"},
+	{Name: "Endpoints", Type: "integer", JSONPath: ".status.endpointCount", Description: "Number of endpoints matching the selector"},
+	{Name: "Message", Type: "string", JSONPath: ".status.message", Description: "Details of the state", Priority: 1},
+	ageColumn,
+}
+
+// NetworkServiceConnectionColumns are the printer columns of
+// NetworkServiceConnections
+var NetworkServiceConnectionColumns = []PrinterColumn{
+	{Name: "Client", Type: "string", JSONPath: ".spec.client.name", Description: "Pod connected"},
+	{Name: "Service", Type: "string", JSONPath: ".spec.networkService", Description: "NetworkService of the connection"},
+	{Name: "Endpoint", Type: "string", JSONPath: ".spec.endpoint.name", Description: "Endpoint selected for the connection"},
+	{Name: "Channel", Type: "string", JSONPath: ".spec.channel", Description: "Channel carrying the connection", Priority: 1},
+	{Name: "Address", Type: "string", JSONPath: ".status.addresses.client", Description: "Address of the client", Priority: 1},
+	{Name: "State", Type: "string", JSONPath: ".status.state", Description: "State of the connection"},
+	{Name: "Message", Type: "string", JSONPath: ".status.message", Description: "Details of the state", Priority: 1},
+	ageColumn,
+}
+
+// NSMFinalizer is added to NetworkServices and NetworkServiceEndpoints by the
+// CRD plugin. It blocks their deletion until the connections through them
+// have been cleaned up.
+const NSMFinalizer string = NSMGroup + "/connections"
+
+// NSMNodeLabel is the label of NetworkServiceEndpoints holding the name of
+// the node the endpoint runs on. spec.node takes precedence, the label is kept
+// so that endpoints can be listed by node with a label selector.
+const NSMNodeLabel string = NSMGroup + "/node"
+
+// NamePattern is the pattern the names of services, channels and endpoints
+// must match, i.e. a DNS-1123 label
+const NamePattern string = "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+
+// Payloads carried by a NetworkServiceChannel, see netmesh.Payload
+const (
+	PayloadEthernet string = netmesh.PayloadNameEthernet
+	PayloadIPv4     string = netmesh.PayloadNameIPv4
+	PayloadIPv6     string = netmesh.PayloadNameIPv6
+	PayloadMPLS     string = netmesh.PayloadNameMPLS
+	PayloadL2VLAN   string = netmesh.PayloadNameL2VLAN
+)
+
+// Payloads lists all the valid channel payloads
+var Payloads = netmesh.PayloadNames()
+
+// LocalMechanisms lists all the valid mechanisms of endpoints and connections,
+// see netmesh.LocalMechanismType
+var LocalMechanisms = netmesh.LocalMechanismNames()
+
+// RemoteMechanisms lists all the valid remote mechanisms of endpoints and
+// connections, see netmesh.RemoteMechanismType
+var RemoteMechanisms = netmesh.RemoteMechanismNames()
+
+// States reported in NetworkServiceStatus by the CRD plugin
+const (
+	// NetworkServiceStateReady means all channels exist and at least one
+	// endpoint matches the selector
+	NetworkServiceStateReady string = "Ready"
+	// NetworkServiceStateNoEndpoints means no endpoint matches the selector
+	NetworkServiceStateNoEndpoints string = "NoEndpoints"
+	// NetworkServiceStateMissingChannel means at least one of the channels
+	// does not exist as a NetworkServiceChannel
+	NetworkServiceStateMissingChannel string = "MissingChannel"
+	// NetworkServiceStatePayloadMismatch means at least one of the channels
+	// cannot carry the payload the service requests from it
+	NetworkServiceStatePayloadMismatch string = "PayloadMismatch"
+	// NetworkServiceStateInvalidSelector means the selector cannot be parsed
+	NetworkServiceStateInvalidSelector string = "InvalidSelector"
+	// NetworkServiceStateMissingImport means at least one of the imported
+	// services does not exist
+	NetworkServiceStateMissingImport string = "MissingImport"
+	// NetworkServiceStateImportDenied means at least one of the imported
+	// services is not exported to the namespace of the service
+	NetworkServiceStateImportDenied string = "ImportDenied"
+	// NetworkServiceStateInvalidPrefix means at least one of the prefixes
+	// cannot be parsed or overlaps another prefix of the service
+	NetworkServiceStateInvalidPrefix string = "InvalidPrefix"
+	// NetworkServiceStatePrefixOverlap means at least one of the prefixes
+	// overlaps a prefix of an older service, no address is allocated to the
+	// connections through the service until the overlap is resolved
+	NetworkServiceStatePrefixOverlap string = "PrefixOverlap"
+	// NetworkServiceStateTerminating means the service is being deleted and
+	// its connections are being cleaned up
+	NetworkServiceStateTerminating string = "Terminating"
+)
+
+// States reported in NetworkServiceEndpointStatus by the CRD plugin
+const (
+	// NetworkServiceEndpointStateTerminating means the endpoint is being
+	// deleted and its connections are being cleaned up
+	NetworkServiceEndpointStateTerminating string = "Terminating"
+	// NetworkServiceEndpointStateHealthy means the endpoint renewed its
+	// heartbeat within the grace period
+	NetworkServiceEndpointStateHealthy string = "Healthy"
+	// NetworkServiceEndpointStateUnhealthy means the endpoint did not renew
+	// its heartbeat within the grace period, it is not selected by any
+	// service until it does
+	NetworkServiceEndpointStateUnhealthy string = "Unhealthy"
+)
+
+// States reported in NetworkServiceChannelStatus by the CRD plugin
+const (
+	// NetworkServiceChannelStateInUse means at least one service uses the
+	// channel
+	NetworkServiceChannelStateInUse string = "InUse"
+	// NetworkServiceChannelStateUnused means no service uses the channel
+	NetworkServiceChannelStateUnused string = "Unused"
+)
+
+// States reported in NetworkServiceConnectionStatus by the CRD plugin
+const (
+	// NetworkServiceConnectionStateEstablished means the service, the
+	// endpoint and the channel of the connection are usable
+	NetworkServiceConnectionStateEstablished string = "Established"
+	// NetworkServiceConnectionStateFailed means the service, the endpoint or
+	// the channel of the connection is no longer usable
+	NetworkServiceConnectionStateFailed string = "Failed"
+)
+
+// ConditionStatus is the status of a condition, one of True, False or Unknown
+type ConditionStatus string
+
+// Values of ConditionStatus
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// ConditionType identifies a condition
+type ConditionType string
+
+// Conditions reported by the CRD plugin
+const (
+	// ConditionReady is True when the object is usable
+	ConditionReady ConditionType = "Ready"
+)
+
+// Condition describes one aspect of the observed state of an object
+type Condition struct {
+	// Type of the condition
+	Type ConditionType `json:"type"`
+	// Status of the condition, one of True, False or Unknown
+	Status ConditionStatus `json:"status"`
+	// Reason is a CamelCase reason for the last transition of the condition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the condition
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the status of the condition changed
+	// +optional
+	LastTransitionTime meta.Time `json:"lastTransitionTime,omitempty"`
+	// ObservedGeneration is the generation of the object the condition was
+	// computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
 // NetworkServiceEndpoint CRD
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -42,10 +255,20 @@ type NetworkServiceEndpoint struct {
 	Status          NetworkServiceEndpointStatus   `json:"status,omitempty"`
 }
 
-//NetworkServiceEndpointStatus is the status schema for this CRD
+// NetworkServiceEndpointStatus is the status schema for this CRD
 type NetworkServiceEndpointStatus struct {
-	State   string `json:"state,omitempty"`
-	Message string `json:"message,omitempty"`
+	State      string      `json:"state,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// LastHeartbeatTime is the last time the endpoint renewed its
+	// heartbeat. Endpoints which never sent a heartbeat are not checked for
+	// liveness.
+	// +optional
+	LastHeartbeatTime meta.Time `json:"lastHeartbeatTime,omitempty"`
+	// UUID is the UUID assigned by the CRD plugin, the UUID of the spec is
+	// restored from it when changed. Only the plugin writes the status.
+	// +optional
+	UUID string `json:"uuid,omitempty"`
 }
 
 // NetworkServiceEndpointList is the list schema for this CRD
@@ -70,8 +293,9 @@ type NetworkServiceChannel struct {
 
 // NetworkServiceChannelStatus is the status schema for this CRD
 type NetworkServiceChannelStatus struct {
-	State   string `json:"state,omitempty"`
-	Message string `json:"message,omitempty"`
+	State      string      `json:"state,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // NetworkServiceChannelList is the list schema for this CRD
@@ -98,6 +322,17 @@ type NetworkService struct {
 type NetworkServiceStatus struct {
 	State   string `json:"state,omitempty"`
 	Message string `json:"message,omitempty"`
+	// ChannelCount is the number of channels of the service
+	ChannelCount int32 `json:"channelCount"`
+	// EndpointCount is the number of endpoints matching the selector
+	EndpointCount int32 `json:"endpointCount"`
+	// Endpoints are the names of the endpoints matching the selector
+	Endpoints  []string    `json:"endpoints,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// UUID is the UUID assigned by the CRD plugin, the UUID of the spec is
+	// restored from it when changed. Only the plugin writes the status.
+	// +optional
+	UUID string `json:"uuid,omitempty"`
 }
 
 // NetworkServiceList is the list schema for this CRD
@@ -109,3 +344,53 @@ type NetworkServiceList struct {
 	meta.ListMeta `json:"metadata,omitempty"`
 	Items         []NetworkService `json:"items"`
 }
+
+// NetworkServiceConnection CRD
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceConnection struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            netmesh.NetworkServiceConnection `json:"spec"`
+	Status          NetworkServiceConnectionStatus   `json:"status,omitempty"`
+}
+
+// NetworkServiceConnectionStatus is the status schema for this CRD
+type NetworkServiceConnectionStatus struct {
+	State      string      `json:"state,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// PayloadAdaptation is how the channel carries the payload requested
+	// by the client, see netmesh.AdaptPayload
+	// +optional
+	PayloadAdaptation string `json:"payloadAdaptation,omitempty"`
+	// Addresses are the addresses of the ends of the connection, allocated
+	// from the prefixes of its service. They are kept across restarts of
+	// the plugin and released along with the connection.
+	// +optional
+	Addresses *ConnectionAddresses `json:"addresses,omitempty"`
+	// UUID is the UUID assigned by the CRD plugin, the UUID of the spec is
+	// restored from it when changed. Only the plugin writes the status.
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+}
+
+// ConnectionAddresses are the addresses of the ends of a connection, in a
+// subnet of its own, see pkg/nsm/ipam
+type ConnectionAddresses struct {
+	// Subnet is the /30 or /127 allocated to the connection, in its CIDR
+	// form
+	Subnet   string `json:"subnet"`
+	Client   string `json:"client"`
+	Endpoint string `json:"endpoint"`
+}
+
+// NetworkServiceConnectionList is the list schema for this CRD
+// -genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceConnectionList struct {
+	meta.TypeMeta `json:",inline"`
+	// +optional
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []NetworkServiceConnection `json:"items"`
+}
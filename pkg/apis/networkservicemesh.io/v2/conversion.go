@@ -0,0 +1,288 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// Objects are stored as v1 and the API server does not convert custom
+// resources, it only rewrites their apiVersion. The v2 schema is therefore kept
+// a superset of v1, with the same JSON encoding, so that objects read and
+// written back through v2 lose nothing: a v2 channel reference is a v1 inline
+// channel. The functions below convert between the Go types of both versions
+// and are registered with the scheme, so that they are used by
+// Scheme.Convert.
+
+// addConversionFuncs registers the conversion functions with the scheme.
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	return scheme.AddConversionFuncs(
+		Convert_v1_NetworkService_To_v2_NetworkService,
+		Convert_v2_NetworkService_To_v1_NetworkService,
+		Convert_v1_NetworkServiceChannel_To_v2_NetworkServiceChannel,
+		Convert_v2_NetworkServiceChannel_To_v1_NetworkServiceChannel,
+		Convert_v1_NetworkServiceEndpoint_To_v2_NetworkServiceEndpoint,
+		Convert_v2_NetworkServiceEndpoint_To_v1_NetworkServiceEndpoint,
+	)
+}
+
+// Convert_v1_NetworkService_To_v2_NetworkService converts a v1 NetworkService
+// to v2. Inline channels become channel references, along with the payload
+// the service requests from them.
+func Convert_v1_NetworkService_To_v2_NetworkService(in *v1.NetworkService, out *NetworkService, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = NetworkServiceSpec{
+		Name:          in.Spec.Name,
+		UUID:          in.Spec.Uuid,
+		Selector:      in.Spec.Selector,
+		LabelSelector: convertLabelSelectorToV2(in.Spec.LabelSelector),
+		ExportTo:      convertExportPolicyToV2(in.Spec.ExportTo),
+		Prefixes:      append([]string(nil), in.Spec.Prefixes...),
+	}
+	for _, channel := range in.Spec.Channels {
+		if channel != nil {
+			out.Spec.Channels = append(out.Spec.Channels, ChannelReference{
+				Name:    channel.Name,
+				Payload: channel.Payload,
+				VLAN:    channel.Vlan,
+			})
+		}
+	}
+	for _, ref := range in.Spec.Imports {
+		if ref != nil {
+			out.Spec.Imports = append(out.Spec.Imports, ServiceReference{Namespace: ref.Namespace, Name: ref.Name})
+		}
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v2_NetworkService_To_v1_NetworkService converts a v2 NetworkService
+// to v1. Channel references become inline channels.
+func Convert_v2_NetworkService_To_v1_NetworkService(in *NetworkService, out *v1.NetworkService, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = netmesh.NetworkService{
+		Name:          in.Spec.Name,
+		Uuid:          in.Spec.UUID,
+		Selector:      in.Spec.Selector,
+		LabelSelector: convertLabelSelectorToV1(in.Spec.LabelSelector),
+		ExportTo:      convertExportPolicyToV1(in.Spec.ExportTo),
+		Prefixes:      append([]string(nil), in.Spec.Prefixes...),
+	}
+	for _, channel := range in.Spec.Channels {
+		out.Spec.Channels = append(out.Spec.Channels, &netmesh.NetworkService_NetmeshChannel{
+			Name:    channel.Name,
+			Payload: channel.Payload,
+			Vlan:    channel.VLAN,
+		})
+	}
+	for _, ref := range in.Spec.Imports {
+		out.Spec.Imports = append(out.Spec.Imports, &netmesh.ServiceReference{Namespace: ref.Namespace, Name: ref.Name})
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v1_NetworkServiceChannel_To_v2_NetworkServiceChannel converts a v1
+// NetworkServiceChannel to v2.
+func Convert_v1_NetworkServiceChannel_To_v2_NetworkServiceChannel(in *v1.NetworkServiceChannel, out *NetworkServiceChannel, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = NetworkServiceChannelSpec{
+		Name:    in.Spec.Name,
+		Payload: in.Spec.Payload,
+		VLAN:    in.Spec.Vlan,
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v2_NetworkServiceChannel_To_v1_NetworkServiceChannel converts a v2
+// NetworkServiceChannel to v1.
+func Convert_v2_NetworkServiceChannel_To_v1_NetworkServiceChannel(in *NetworkServiceChannel, out *v1.NetworkServiceChannel, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = netmesh.NetworkService_NetmeshChannel{
+		Name:    in.Spec.Name,
+		Payload: in.Spec.Payload,
+		Vlan:    in.Spec.VLAN,
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v1_NetworkServiceEndpoint_To_v2_NetworkServiceEndpoint converts a v1
+// NetworkServiceEndpoint to v2.
+func Convert_v1_NetworkServiceEndpoint_To_v2_NetworkServiceEndpoint(in *v1.NetworkServiceEndpoint, out *NetworkServiceEndpoint, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = NetworkServiceEndpointSpec{
+		Name:             in.Spec.Name,
+		UUID:             in.Spec.Uuid,
+		Node:             in.Spec.Node,
+		Pod:              convertPodReferenceToV2(in.Spec.Pod),
+		Socket:           in.Spec.Socket,
+		Labels:           copyLabels(in.Spec.Labels),
+		Mechanisms:       append([]string(nil), in.Spec.Mechanisms...),
+		Capacity:         in.Spec.Capacity,
+		RemoteMechanisms: append([]string(nil), in.Spec.RemoteMechanisms...),
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v2_NetworkServiceEndpoint_To_v1_NetworkServiceEndpoint converts a v2
+// NetworkServiceEndpoint to v1.
+func Convert_v2_NetworkServiceEndpoint_To_v1_NetworkServiceEndpoint(in *NetworkServiceEndpoint, out *v1.NetworkServiceEndpoint, s conversion.Scope) error {
+	convertTypeMeta(&in.TypeMeta, &out.TypeMeta)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = netmesh.NetworkServiceEndpoint{
+		Name:             in.Spec.Name,
+		Uuid:             in.Spec.UUID,
+		Node:             in.Spec.Node,
+		Pod:              convertPodReferenceToV1(in.Spec.Pod),
+		Socket:           in.Spec.Socket,
+		Labels:           copyLabels(in.Spec.Labels),
+		Mechanisms:       append([]string(nil), in.Spec.Mechanisms...),
+		Capacity:         in.Spec.Capacity,
+		RemoteMechanisms: append([]string(nil), in.Spec.RemoteMechanisms...),
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return nil
+}
+
+// convertLabelSelectorToV2 converts the label selector of the netmesh model to
+// its Kubernetes counterpart, which has the same JSON encoding.
+func convertLabelSelectorToV2(in *netmesh.LabelSelector) *meta.LabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := &meta.LabelSelector{}
+	if in.MatchLabels != nil {
+		out.MatchLabels = make(map[string]string, len(in.MatchLabels))
+		for key, value := range in.MatchLabels {
+			out.MatchLabels[key] = value
+		}
+	}
+	for _, expression := range in.MatchExpressions {
+		if expression != nil {
+			out.MatchExpressions = append(out.MatchExpressions, meta.LabelSelectorRequirement{
+				Key:      expression.Key,
+				Operator: meta.LabelSelectorOperator(expression.Operator),
+				Values:   append([]string(nil), expression.Values...),
+			})
+		}
+	}
+	return out
+}
+
+// convertLabelSelectorToV1 converts a Kubernetes label selector to the label
+// selector of the netmesh model.
+func convertLabelSelectorToV1(in *meta.LabelSelector) *netmesh.LabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := &netmesh.LabelSelector{}
+	if in.MatchLabels != nil {
+		out.MatchLabels = make(map[string]string, len(in.MatchLabels))
+		for key, value := range in.MatchLabels {
+			out.MatchLabels[key] = value
+		}
+	}
+	for _, expression := range in.MatchExpressions {
+		out.MatchExpressions = append(out.MatchExpressions, &netmesh.LabelSelectorRequirement{
+			Key:      expression.Key,
+			Operator: string(expression.Operator),
+			Values:   append([]string(nil), expression.Values...),
+		})
+	}
+	return out
+}
+
+// convertExportPolicyToV2 converts the export policy of the netmesh model to
+// v2.
+func convertExportPolicyToV2(in *netmesh.ExportPolicy) *ExportPolicy {
+	if in == nil {
+		return nil
+	}
+	return &ExportPolicy{
+		Namespaces:        append([]string(nil), in.Namespaces...),
+		NamespaceSelector: convertLabelSelectorToV2(in.NamespaceSelector),
+	}
+}
+
+// convertExportPolicyToV1 converts a v2 export policy to the export policy of
+// the netmesh model.
+func convertExportPolicyToV1(in *ExportPolicy) *netmesh.ExportPolicy {
+	if in == nil {
+		return nil
+	}
+	return &netmesh.ExportPolicy{
+		Namespaces:        append([]string(nil), in.Namespaces...),
+		NamespaceSelector: convertLabelSelectorToV1(in.NamespaceSelector),
+	}
+}
+
+// convertPodReferenceToV2 converts the pod reference of the netmesh model to
+// v2.
+func convertPodReferenceToV2(in *netmesh.PodReference) *PodReference {
+	if in == nil {
+		return nil
+	}
+	return &PodReference{Name: in.Name, UID: in.Uid}
+}
+
+// convertPodReferenceToV1 converts a v2 pod reference to the pod reference of
+// the netmesh model.
+func convertPodReferenceToV1(in *PodReference) *netmesh.PodReference {
+	if in == nil {
+		return nil
+	}
+	return &netmesh.PodReference{Name: in.Name, Uid: in.UID}
+}
+
+// copyLabels returns a copy of a label map, nil if it is empty.
+func copyLabels(in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
+
+// convertTypeMeta copies the kind and, if the source has one, points the
+// apiVersion to the other version.
+func convertTypeMeta(in, out *meta.TypeMeta) {
+	out.Kind = in.Kind
+	out.APIVersion = ""
+	if in.APIVersion == "" {
+		return
+	}
+	if in.APIVersion == SchemeGroupVersion.String() {
+		out.APIVersion = v1.SchemeGroupVersion.String()
+	} else {
+		out.APIVersion = SchemeGroupVersion.String()
+	}
+}
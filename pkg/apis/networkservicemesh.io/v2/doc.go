@@ -0,0 +1,25 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +k8s:deepcopy-gen=package
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=networkservicemesh.io
+// +groupGoName=Networkservice
+
+// Package v2 contains the v2 API of the NSM custom resources. Unlike v1, its
+// specs are plain Go types rather than netmesh protobuf messages, and
+// NetworkServices reference their channels by name instead of inlining them.
+// Objects are stored as v1 and the v2 schema is a superset of v1, see
+// conversion.go.
+package v2
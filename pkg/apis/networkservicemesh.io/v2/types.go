@@ -0,0 +1,190 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// Constants to register CRDs for our resources, the names of the resources
+// are shared with v1
+const (
+	NSMGroup        string = v1.NSMGroup
+	NSMGroupVersion string = "v2"
+)
+
+// NetworkServiceEndpoint CRD
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceEndpoint struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            NetworkServiceEndpointSpec      `json:"spec"`
+	Status          v1.NetworkServiceEndpointStatus `json:"status,omitempty"`
+}
+
+// NetworkServiceEndpointSpec is the spec schema for this CRD
+type NetworkServiceEndpointSpec struct {
+	Name string `json:"name"`
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+	// Node is the name of the node the endpoint runs on
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Pod references the pod providing the endpoint
+	// +optional
+	Pod *PodReference `json:"pod,omitempty"`
+	// Socket is the path of the NSM socket through which the endpoint is
+	// reached
+	// +optional
+	Socket string `json:"socket,omitempty"`
+	// Labels are advertised by the endpoint to its clients
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Mechanisms lists the mechanisms the endpoint supports, in order of
+	// preference
+	// +optional
+	Mechanisms []string `json:"mechanisms,omitempty"`
+	// Capacity is the maximum number of connections, 0 for unlimited
+	// +optional
+	Capacity uint32 `json:"capacity,omitempty"`
+	// RemoteMechanisms lists the mechanisms carrying connections from
+	// clients on other nodes the endpoint supports
+	// +optional
+	RemoteMechanisms []string `json:"remoteMechanisms,omitempty"`
+}
+
+// PodReference references a pod of the namespace of the endpoint
+type PodReference struct {
+	Name string `json:"name"`
+	// +optional
+	UID string `json:"uid,omitempty"`
+}
+
+// NetworkServiceEndpointList is the list schema for this CRD
+// -genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceEndpointList struct {
+	meta.TypeMeta `json:",inline"`
+	// +optional
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []NetworkServiceEndpoint `json:"items"`
+}
+
+// NetworkServiceChannel CRD
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceChannel struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            NetworkServiceChannelSpec      `json:"spec"`
+	Status          v1.NetworkServiceChannelStatus `json:"status,omitempty"`
+}
+
+// NetworkServiceChannelSpec is the spec schema for this CRD
+type NetworkServiceChannelSpec struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
+	// VLAN is the VLAN ID of channels carrying the l2-vlan payload
+	// +optional
+	VLAN uint32 `json:"vlan,omitempty"`
+}
+
+// NetworkServiceChannelList is the list schema for this CRD
+// -genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceChannelList struct {
+	meta.TypeMeta `json:",inline"`
+	// +optional
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []NetworkServiceChannel `json:"items"`
+}
+
+// NetworkService CRD
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkService struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            NetworkServiceSpec      `json:"spec"`
+	Status          v1.NetworkServiceStatus `json:"status,omitempty"`
+}
+
+// NetworkServiceSpec is the spec schema for this CRD
+type NetworkServiceSpec struct {
+	Name string `json:"name"`
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+	// Selector is a label selector in its string form
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// LabelSelector selects the endpoints of the service, in addition to
+	// Selector
+	// +optional
+	LabelSelector *meta.LabelSelector `json:"labelSelector,omitempty"`
+	// Channels references NetworkServiceChannels in the namespace of the
+	// service, which define the payload they carry
+	// +optional
+	Channels []ChannelReference `json:"channels,omitempty"`
+	// ExportTo lists the namespaces allowed to import the service
+	// +optional
+	ExportTo *ExportPolicy `json:"exportTo,omitempty"`
+	// Imports lists services of other namespaces whose endpoints are
+	// selected in addition to the endpoints of the namespace of the service
+	// +optional
+	Imports []ServiceReference `json:"imports,omitempty"`
+	// Prefixes are the pools of the addresses of the connections through
+	// the service, in their CIDR form
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// ChannelReference references a NetworkServiceChannel by name
+type ChannelReference struct {
+	Name string `json:"name"`
+	// Payload is the payload the service requests from the channel, any
+	// payload the channel can carry when empty
+	// +optional
+	Payload string `json:"payload,omitempty"`
+	// VLAN is the VLAN ID requested along with the l2-vlan payload
+	// +optional
+	VLAN uint32 `json:"vlan,omitempty"`
+}
+
+// ExportPolicy selects namespaces by name or by their labels
+type ExportPolicy struct {
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// +optional
+	NamespaceSelector *meta.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ServiceReference references a NetworkService of another namespace
+type ServiceReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// NetworkServiceList is the list schema for this CRD
+// -genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkServiceList struct {
+	meta.TypeMeta `json:",inline"`
+	// +optional
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []NetworkService `json:"items"`
+}
@@ -19,6 +19,7 @@ package versioned
 import (
 	glog "github.com/golang/glog"
 	networkservicev1 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v1"
+	networkservicev2 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v2"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -27,6 +28,7 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	NetworkserviceV1() networkservicev1.NetworkserviceV1Interface
+	NetworkserviceV2() networkservicev2.NetworkserviceV2Interface
 	// Deprecated: please explicitly pick a version if possible.
 	Networkservice() networkservicev1.NetworkserviceV1Interface
 }
@@ -36,6 +38,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	networkserviceV1 *networkservicev1.NetworkserviceV1Client
+	networkserviceV2 *networkservicev2.NetworkserviceV2Client
 }
 
 // NetworkserviceV1 retrieves the NetworkserviceV1Client
@@ -43,6 +46,11 @@ func (c *Clientset) NetworkserviceV1() networkservicev1.NetworkserviceV1Interfac
 	return c.networkserviceV1
 }
 
+// NetworkserviceV2 retrieves the NetworkserviceV2Client
+func (c *Clientset) NetworkserviceV2() networkservicev2.NetworkserviceV2Interface {
+	return c.networkserviceV2
+}
+
 // Deprecated: Networkservice retrieves the default version of NetworkserviceClient.
 // Please explicitly pick a version.
 func (c *Clientset) Networkservice() networkservicev1.NetworkserviceV1Interface {
@@ -69,6 +77,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.networkserviceV2, err = networkservicev2.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -83,6 +95,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.networkserviceV1 = networkservicev1.NewForConfigOrDie(c)
+	cs.networkserviceV2 = networkservicev2.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -92,6 +105,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.networkserviceV1 = networkservicev1.New(c)
+	cs.networkserviceV2 = networkservicev2.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -20,6 +20,8 @@ import (
 	clientset "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned"
 	networkservicev1 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v1"
 	fakenetworkservicev1 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v1/fake"
+	networkservicev2 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v2"
+	fakenetworkservicev2 "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/typed/networkservicemesh.io/v2/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -73,6 +75,11 @@ func (c *Clientset) NetworkserviceV1() networkservicev1.NetworkserviceV1Interfac
 	return &fakenetworkservicev1.FakeNetworkserviceV1{Fake: &c.Fake}
 }
 
+// NetworkserviceV2 retrieves the NetworkserviceV2Client
+func (c *Clientset) NetworkserviceV2() networkservicev2.NetworkserviceV2Interface {
+	return &fakenetworkservicev2.FakeNetworkserviceV2{Fake: &c.Fake}
+}
+
 // Networkservice retrieves the NetworkserviceV1Client
 func (c *Clientset) Networkservice() networkservicev1.NetworkserviceV1Interface {
 	return &fakenetworkservicev1.FakeNetworkserviceV1{Fake: &c.Fake}
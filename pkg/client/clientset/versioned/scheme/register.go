@@ -18,6 +18,7 @@ package scheme
 
 import (
 	networkservicev1 "github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	networkservicev2 "github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -49,4 +50,5 @@ func init() {
 // correctly.
 func AddToScheme(scheme *runtime.Scheme) {
 	networkservicev1.AddToScheme(scheme)
+	networkservicev2.AddToScheme(scheme)
 }
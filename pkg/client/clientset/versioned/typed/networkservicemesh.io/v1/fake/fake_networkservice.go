@@ -32,9 +32,9 @@ type FakeNetworkServices struct {
 	ns   string
 }
 
-var networkservicesResource = schema.GroupVersionResource{Group: "networkservice.mesh", Version: "v1", Resource: "networkservices"}
+var networkservicesResource = schema.GroupVersionResource{Group: "networkservicemesh.io", Version: "v1", Resource: "networkservices"}
 
-var networkservicesKind = schema.GroupVersionKind{Group: "networkservice.mesh", Version: "v1", Kind: "NetworkService"}
+var networkservicesKind = schema.GroupVersionKind{Group: "networkservicemesh.io", Version: "v1", Kind: "NetworkService"}
 
 // Get takes name of the networkService, and returns the corresponding networkService object, and an error if there is any.
 func (c *FakeNetworkServices) Get(name string, options v1.GetOptions) (result *networkservicemesh_io_v1.NetworkService, err error) {
@@ -32,9 +32,9 @@ type FakeNetworkServiceChannels struct {
 	ns   string
 }
 
-var networkservicechannelsResource = schema.GroupVersionResource{Group: "networkservice.mesh", Version: "v1", Resource: "networkservicechannels"}
+var networkservicechannelsResource = schema.GroupVersionResource{Group: "networkservicemesh.io", Version: "v1", Resource: "networkservicechannels"}
 
-var networkservicechannelsKind = schema.GroupVersionKind{Group: "networkservice.mesh", Version: "v1", Kind: "NetworkServiceChannel"}
+var networkservicechannelsKind = schema.GroupVersionKind{Group: "networkservicemesh.io", Version: "v1", Kind: "NetworkServiceChannel"}
 
 // Get takes name of the networkServiceChannel, and returns the corresponding networkServiceChannel object, and an error if there is any.
 func (c *FakeNetworkServiceChannels) Get(name string, options v1.GetOptions) (result *networkservicemesh_io_v1.NetworkServiceChannel, err error) {
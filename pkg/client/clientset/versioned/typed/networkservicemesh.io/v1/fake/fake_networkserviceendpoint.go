@@ -32,9 +32,9 @@ type FakeNetworkServiceEndpoints struct {
 	ns   string
 }
 
-var networkserviceendpointsResource = schema.GroupVersionResource{Group: "networkservice.mesh", Version: "v1", Resource: "networkserviceendpoints"}
+var networkserviceendpointsResource = schema.GroupVersionResource{Group: "networkservicemesh.io", Version: "v1", Resource: "networkserviceendpoints"}
 
-var networkserviceendpointsKind = schema.GroupVersionKind{Group: "networkservice.mesh", Version: "v1", Kind: "NetworkServiceEndpoint"}
+var networkserviceendpointsKind = schema.GroupVersionKind{Group: "networkservicemesh.io", Version: "v1", Kind: "NetworkServiceEndpoint"}
 
 // Get takes name of the networkServiceEndpoint, and returns the corresponding networkServiceEndpoint object, and an error if there is any.
 func (c *FakeNetworkServiceEndpoints) Get(name string, options v1.GetOptions) (result *networkservicemesh_io_v1.NetworkServiceEndpoint, err error) {
@@ -34,6 +34,10 @@ func (c *FakeNetworkserviceV1) NetworkServiceChannels(namespace string) v1.Netwo
 	return &FakeNetworkServiceChannels{c, namespace}
 }
 
+func (c *FakeNetworkserviceV1) NetworkServiceConnections(namespace string) v1.NetworkServiceConnectionInterface {
+	return &FakeNetworkServiceConnections{c, namespace}
+}
+
 func (c *FakeNetworkserviceV1) NetworkServiceEndpoints(namespace string) v1.NetworkServiceEndpointInterface {
 	return &FakeNetworkServiceEndpoints{c, namespace}
 }
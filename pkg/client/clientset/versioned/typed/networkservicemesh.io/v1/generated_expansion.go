@@ -20,4 +20,6 @@ type NetworkServiceExpansion interface{}
 
 type NetworkServiceChannelExpansion interface{}
 
+type NetworkServiceConnectionExpansion interface{}
+
 type NetworkServiceEndpointExpansion interface{}
@@ -27,10 +27,11 @@ type NetworkserviceV1Interface interface {
 	RESTClient() rest.Interface
 	NetworkServicesGetter
 	NetworkServiceChannelsGetter
+	NetworkServiceConnectionsGetter
 	NetworkServiceEndpointsGetter
 }
 
-// NetworkserviceV1Client is used to interact with features provided by the networkservice.mesh group.
+// NetworkserviceV1Client is used to interact with features provided by the networkservicemesh.io group.
 type NetworkserviceV1Client struct {
 	restClient rest.Interface
 }
@@ -43,6 +44,10 @@ func (c *NetworkserviceV1Client) NetworkServiceChannels(namespace string) Networ
 	return newNetworkServiceChannels(c, namespace)
 }
 
+func (c *NetworkserviceV1Client) NetworkServiceConnections(namespace string) NetworkServiceConnectionInterface {
+	return newNetworkServiceConnections(c, namespace)
+}
+
 func (c *NetworkserviceV1Client) NetworkServiceEndpoints(namespace string) NetworkServiceEndpointInterface {
 	return newNetworkServiceEndpoints(c, namespace)
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1 "github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	v2 "github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v2"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -50,14 +51,24 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=networkservice.mesh, Version=v1
+	// Group=networkservicemesh.io, Version=v1
 	case v1.SchemeGroupVersion.WithResource("networkservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V1().NetworkServices().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("networkservicechannels"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V1().NetworkServiceChannels().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("networkserviceconnections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V1().NetworkServiceConnections().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("networkserviceendpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V1().NetworkServiceEndpoints().Informer()}, nil
 
+		// Group=networkservicemesh.io, Version=v2
+	case v2.SchemeGroupVersion.WithResource("networkservices"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V2().NetworkServices().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("networkservicechannels"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V2().NetworkServiceChannels().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("networkserviceendpoints"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networkservice().V2().NetworkServiceEndpoints().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -26,6 +26,8 @@ type Interface interface {
 	NetworkServices() NetworkServiceInformer
 	// NetworkServiceChannels returns a NetworkServiceChannelInformer.
 	NetworkServiceChannels() NetworkServiceChannelInformer
+	// NetworkServiceConnections returns a NetworkServiceConnectionInformer.
+	NetworkServiceConnections() NetworkServiceConnectionInformer
 	// NetworkServiceEndpoints returns a NetworkServiceEndpointInformer.
 	NetworkServiceEndpoints() NetworkServiceEndpointInformer
 }
@@ -51,6 +53,11 @@ func (v *version) NetworkServiceChannels() NetworkServiceChannelInformer {
 	return &networkServiceChannelInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NetworkServiceConnections returns a NetworkServiceConnectionInformer.
+func (v *version) NetworkServiceConnections() NetworkServiceConnectionInformer {
+	return &networkServiceConnectionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // NetworkServiceEndpoints returns a NetworkServiceEndpointInformer.
 func (v *version) NetworkServiceEndpoints() NetworkServiceEndpointInformer {
 	return &networkServiceEndpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -32,6 +32,14 @@ type NetworkServiceChannelListerExpansion interface{}
 // NetworkServiceChannelNamespaceLister.
 type NetworkServiceChannelNamespaceListerExpansion interface{}
 
+// NetworkServiceConnectionListerExpansion allows custom methods to be added to
+// NetworkServiceConnectionLister.
+type NetworkServiceConnectionListerExpansion interface{}
+
+// NetworkServiceConnectionNamespaceListerExpansion allows custom methods to be added to
+// NetworkServiceConnectionNamespaceLister.
+type NetworkServiceConnectionNamespaceListerExpansion interface{}
+
 // NetworkServiceEndpointListerExpansion allows custom methods to be added to
 // NetworkServiceEndpointLister.
 type NetworkServiceEndpointListerExpansion interface{}
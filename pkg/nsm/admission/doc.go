@@ -0,0 +1,22 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission implements the admission review of NSM objects, as done
+// by the Kubernetes API server through admission webhooks. The mutating
+// review fills in the defaults of the objects, the validating review rejects
+// objects the CRD schema cannot catch, e.g. NetworkServices referencing
+// channels which do not exist.
+//
+// Reviews are exchanged as AdmissionReview JSON documents, see Review.
+package admission
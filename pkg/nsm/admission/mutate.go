@@ -0,0 +1,187 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/satori/go.uuid"
+	admission "k8s.io/api/admission/v1beta1"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// Kinds of the NSM objects
+var (
+	networkServiceKind         = reflect.TypeOf(v1.NetworkService{}).Name()
+	networkServiceChannelKind  = reflect.TypeOf(v1.NetworkServiceChannel{}).Name()
+	networkServiceEndpointKind = reflect.TypeOf(v1.NetworkServiceEndpoint{}).Name()
+)
+
+// DefaultPayload is the payload of channels created without one
+const DefaultPayload = v1.PayloadEthernet
+
+// patchOperation is an operation of a JSON patch, see RFC 6902.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// mutate fills in the defaults of created and updated objects. The spec is
+// patched as a whole when any of its fields changed.
+func (r *Reviewer) mutate(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	if req.Operation != admission.Create && req.Operation != admission.Update {
+		return allow()
+	}
+
+	var spec interface{}
+	var changed bool
+	switch req.Kind.Kind {
+	case networkServiceKind:
+		ns := &v1.NetworkService{}
+		if err := json.Unmarshal(req.Object.Raw, ns); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		changed = defaultNetworkService(ns, previousUUID(req))
+		spec = &ns.Spec
+	case networkServiceChannelKind:
+		nsc := &v1.NetworkServiceChannel{}
+		if err := json.Unmarshal(req.Object.Raw, nsc); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		changed = defaultNetworkServiceChannel(nsc)
+		spec = &nsc.Spec
+	case networkServiceEndpointKind:
+		nse := &v1.NetworkServiceEndpoint{}
+		if err := json.Unmarshal(req.Object.Raw, nse); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		changed = defaultNetworkServiceEndpoint(nse, previousUUID(req))
+		spec = &nse.Spec
+	default:
+		return allow()
+	}
+	if !changed {
+		return allow()
+	}
+
+	// An add operation replaces the member if it exists already
+	patch, err := json.Marshal([]patchOperation{{Op: "add", Path: "/spec", Value: spec}})
+	if err != nil {
+		return deny(http.StatusInternalServerError, "error encoding patch: %s", err)
+	}
+	patchType := admission.PatchTypeJSONPatch
+	return &admission.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// defaultNetworkService fills in the UUID and the name of a NetworkService
+// and normalizes the names of its channels. previous is the UUID of the object
+// before an update. It returns true if the spec was changed.
+func defaultNetworkService(ns *v1.NetworkService, previous string) bool {
+	changed := defaultName(&ns.Spec.Name, ns.Name)
+	changed = defaultUUID(&ns.Spec.Uuid, previous) || changed
+	for _, channel := range ns.Spec.Channels {
+		if channel != nil {
+			changed = normalizeName(&channel.Name) || changed
+		}
+	}
+	return changed
+}
+
+// defaultNetworkServiceChannel fills in the name and the payload of a
+// NetworkServiceChannel. It returns true if the spec was changed.
+func defaultNetworkServiceChannel(nsc *v1.NetworkServiceChannel) bool {
+	changed := defaultName(&nsc.Spec.Name, nsc.Name)
+	changed = normalizeName(&nsc.Spec.Name) || changed
+	if nsc.Spec.Payload == "" {
+		nsc.Spec.Payload = DefaultPayload
+		changed = true
+	}
+	return changed
+}
+
+// defaultNetworkServiceEndpoint fills in the UUID and the name of a
+// NetworkServiceEndpoint. previous is the UUID of the object before an
+// update. It returns true if the spec was changed.
+func defaultNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint, previous string) bool {
+	changed := defaultName(&nse.Spec.Name, nse.Name)
+	return defaultUUID(&nse.Spec.Uuid, previous) || changed
+}
+
+// defaultName sets an empty spec name to the name of the object, if known.
+func defaultName(name *string, objectName string) bool {
+	if *name != "" || objectName == "" {
+		return false
+	}
+	*name = objectName
+	return true
+}
+
+// defaultUUID keeps the previous UUID of an updated object, or generates a
+// UUID if none is set.
+func defaultUUID(id *string, previous string) bool {
+	if *id != "" {
+		return false
+	}
+	*id = previous
+	if *id == "" {
+		*id = uuid.NewV4().String()
+	}
+	return true
+}
+
+// previousUUID returns the UUID of the object before an update, if any.
+func previousUUID(req *admission.AdmissionRequest) string {
+	if req.Operation != admission.Update || len(req.OldObject.Raw) == 0 {
+		return ""
+	}
+	id, err := specUUID(req.OldObject.Raw)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// specUUID decodes the UUID of an encoded object, NetworkServices and
+// NetworkServiceEndpoints both have spec.uuid.
+func specUUID(raw []byte) (string, error) {
+	obj := struct {
+		Spec struct {
+			UUID string `json:"uuid"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return "", err
+	}
+	return obj.Spec.UUID, nil
+}
+
+// normalizeName lower cases a name and trims the surrounding spaces.
+func normalizeName(name *string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(*name))
+	if normalized == *name {
+		return false
+	}
+	*name = normalized
+	return true
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admission "k8s.io/api/admission/v1beta1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// Paths the reviews are served on by Handler
+const (
+	MutatePath   = "/mutate"
+	ValidatePath = "/validate"
+)
+
+// ChannelGetter looks up NetworkServiceChannels, it must return a NotFound
+// error when the channel does not exist. It is implemented by the API of the
+// CRD plugin.
+type ChannelGetter interface {
+	GetNetworkServiceChannel(namespace, name string) (*v1.NetworkServiceChannel, error)
+}
+
+// Reviewer reviews the admission of NSM objects.
+type Reviewer struct {
+	// Channels is used to check the channels referenced by NetworkServices
+	Channels ChannelGetter
+}
+
+// reviewFunc reviews a single admission request.
+type reviewFunc func(req *admission.AdmissionRequest) *admission.AdmissionResponse
+
+// Mutate reviews an AdmissionReview JSON document with the mutating review
+// and returns the AdmissionReview holding the response.
+func (r *Reviewer) Mutate(body []byte) ([]byte, error) {
+	return review(body, r.mutate)
+}
+
+// Validate reviews an AdmissionReview JSON document with the validating
+// review and returns the AdmissionReview holding the response.
+func (r *Reviewer) Validate(body []byte) ([]byte, error) {
+	return review(body, r.validate)
+}
+
+// Handler returns the HTTP handler serving the mutating review on MutatePath
+// and the validating review on ValidatePath.
+func (r *Reviewer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MutatePath, serve(r.Mutate))
+	mux.HandleFunc(ValidatePath, serve(r.Validate))
+	return mux
+}
+
+// review decodes an AdmissionReview, reviews its request and encodes the
+// response.
+func review(body []byte, f reviewFunc) ([]byte, error) {
+	ar := admission.AdmissionReview{}
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return nil, fmt.Errorf("error decoding AdmissionReview: %s", err)
+	}
+	if ar.Request == nil {
+		return nil, fmt.Errorf("AdmissionReview without request")
+	}
+
+	response := f(ar.Request)
+	response.UID = ar.Request.UID
+	ar.Response = response
+	ar.Request = nil
+
+	return json.Marshal(&ar)
+}
+
+// serve wraps a review into an HTTP handler.
+func serve(review func(body []byte) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := req.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		out, err := review(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(out)
+	}
+}
+
+// allow returns a response admitting the object unchanged.
+func allow() *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{Allowed: true}
+}
+
+// deny returns a response rejecting the object with the given reason.
+func deny(code int32, format string, args ...interface{}) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &meta.Status{
+			Status:  meta.StatusFailure,
+			Message: fmt.Sprintf(format, args...),
+			Reason:  meta.StatusReasonInvalid,
+			Code:    code,
+		},
+	}
+}
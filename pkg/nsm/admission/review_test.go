@@ -0,0 +1,244 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/satori/go.uuid"
+	admission "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// testChannels is a ChannelGetter of the channels of the default namespace.
+type testChannels map[string]*v1.NetworkServiceChannel
+
+func (c testChannels) GetNetworkServiceChannel(namespace, name string) (*v1.NetworkServiceChannel, error) {
+	if nsc, ok := c[name]; ok && namespace == "default" {
+		return nsc, nil
+	}
+	return nil, apierrors.NewNotFound(v1.Resource(v1.NSMChannelPlural), name)
+}
+
+func testReviewer() *Reviewer {
+	channels := testChannels{}
+	for _, name := range []string{"gold-channel", "silver-channel"} {
+		channels[name] = &v1.NetworkServiceChannel{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       netmesh.NetworkService_NetmeshChannel{Name: name, Payload: v1.PayloadEthernet},
+		}
+	}
+	return &Reviewer{Channels: channels}
+}
+
+// reviewFixture posts the AdmissionReview of a file of the testdata directory
+// to the handler of the reviewer and returns the response it holds.
+func reviewFixture(t *testing.T, reviewer *Reviewer, path, file string) *admission.AdmissionResponse {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatalf("error reading %s: %s", file, err)
+	}
+	request := admission.AdmissionReview{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("error decoding %s: %s", file, err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	reviewer.Handler().ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body)
+	}
+
+	review := admission.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &review); err != nil {
+		t.Fatalf("error decoding the review of %s: %s", file, err)
+	}
+	if review.Request != nil || review.Response == nil {
+		t.Fatalf("expected a review holding only a response, got %+v", review)
+	}
+	if review.Response.UID != request.Request.UID {
+		t.Errorf("expected the response UID %s, got %s", request.Request.UID, review.Response.UID)
+	}
+	return review.Response
+}
+
+// patchedSpec returns the spec set by the JSON patch of a response.
+func patchedSpec(t *testing.T, response *admission.AdmissionResponse) map[string]interface{} {
+	if response.PatchType == nil || *response.PatchType != admission.PatchTypeJSONPatch {
+		t.Fatalf("expected a JSON patch, got patch type %v", response.PatchType)
+	}
+	var patch []struct {
+		Op    string                 `json:"op"`
+		Path  string                 `json:"path"`
+		Value map[string]interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(response.Patch, &patch); err != nil {
+		t.Fatalf("error decoding patch %s: %s", response.Patch, err)
+	}
+	if len(patch) != 1 || patch[0].Op != "add" || patch[0].Path != "/spec" {
+		t.Fatalf("expected the spec to be replaced, got patch %s", response.Patch)
+	}
+	return patch[0].Value
+}
+
+func TestMutate(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		// spec is the patched spec without its UUID, empty if the object
+		// is not patched
+		spec string
+		// uuid is the UUID of the patched spec, newUUID is set if a new
+		// UUID is generated
+		uuid    string
+		newUUID bool
+	}{
+		{
+			name:    "UUID and name filled in and channel names normalized",
+			file:    "mutate-service-create.json",
+			spec:    `{"name": "gold-network", "selector": "app=gold", "channels": [{"name": "gold-channel"}, {"name": "silver-channel"}]}`,
+			newUUID: true,
+		},
+		{
+			name: "UUID kept across updates",
+			file: "mutate-service-update.json",
+			spec: `{"name": "gold-network", "selector": "app=platinum", "channels": [{"name": "gold-channel"}]}`,
+			uuid: "1f0e6a66-53f7-4b5c-9a8e-2a1f6c3d9e21",
+		},
+		{
+			name: "default payload",
+			file: "mutate-channel-create.json",
+			spec: `{"name": "gold-channel", "payload": "ethernet"}`,
+		},
+		{
+			name: "defaults already set",
+			file: "mutate-endpoint-defaulted.json",
+		},
+	}
+
+	reviewer := testReviewer()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := reviewFixture(t, reviewer, MutatePath, test.file)
+			if !response.Allowed {
+				t.Fatalf("mutation denied: %v", response.Result)
+			}
+			if test.spec == "" {
+				if response.Patch != nil || response.PatchType != nil {
+					t.Fatalf("expected no patch, got %s", response.Patch)
+				}
+				return
+			}
+
+			spec := patchedSpec(t, response)
+			id, _ := spec["uuid"].(string)
+			delete(spec, "uuid")
+			if test.newUUID {
+				if _, err := uuid.FromString(id); err != nil {
+					t.Errorf("expected a generated UUID, got %q: %s", id, err)
+				}
+			} else if id != test.uuid {
+				t.Errorf("expected UUID %q, got %q", test.uuid, id)
+			}
+			expected := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(test.spec), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(spec, expected) {
+				t.Errorf("expected spec %v, got %v", expected, spec)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		// message is a part of the reason of the denial, empty if the
+		// object is allowed
+		message string
+	}{
+		{"valid service", "validate-service.json", ""},
+		{"missing channel", "validate-missing-channel.json", "channel bronze-channel does not exist in namespace default"},
+		{"duplicate channel", "validate-duplicate-channel.json", "channel gold-channel is listed more than once"},
+		{"bad selector", "validate-bad-selector.json", "tier in (gold"},
+		{"name mismatch", "validate-name-mismatch.json", `spec.name "silver-endpoint" does not match metadata.name "gold-endpoint"`},
+		{"UUID changed", "validate-uuid-changed.json", "spec.uuid is immutable"},
+		{"UUID of a deleted object changed", "validate-uuid-changed-deleted.json", "spec.uuid is immutable"},
+	}
+
+	reviewer := testReviewer()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := reviewFixture(t, reviewer, ValidatePath, test.file)
+			if test.message == "" {
+				if !response.Allowed {
+					t.Fatalf("expected the object to be allowed, got %v", response.Result)
+				}
+				return
+			}
+			if response.Allowed || response.Result == nil {
+				t.Fatal("expected the object to be denied")
+			}
+			if response.Result.Code != http.StatusUnprocessableEntity || !strings.Contains(response.Result.Message, test.message) {
+				t.Errorf("expected a %d denial containing %q, got %d: %s",
+					http.StatusUnprocessableEntity, test.message, response.Result.Code, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		code        int
+	}{
+		{"GET", http.MethodGet, "application/json", "", http.StatusMethodNotAllowed},
+		{"YAML", http.MethodPost, "application/yaml", "{}", http.StatusUnsupportedMediaType},
+		{"malformed review", http.MethodPost, "application/json", "{", http.StatusBadRequest},
+		{"review without request", http.MethodPost, "application/json", `{"kind": "AdmissionReview"}`, http.StatusBadRequest},
+	}
+
+	handler := testReviewer().Handler()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, ValidatePath, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != test.code {
+				t.Errorf("expected status %d, got %d", test.code, recorder.Code)
+			}
+		})
+	}
+}
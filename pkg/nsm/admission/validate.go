@@ -0,0 +1,249 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+
+	admission "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/ipam"
+	"github.com/ligato/networkservicemesh/pkg/nsm/selector"
+)
+
+// validate rejects created and updated objects which are not consistent,
+// either on their own or with the other objects of the cluster. Updates which
+// leave the spec unchanged, such as the removal of a finalizer, and updates of
+// objects being deleted are allowed unless they change the UUID: the channels
+// of a service may be gone by then, which must not keep the service from being
+// deleted.
+func (r *Reviewer) validate(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	if req.Operation != admission.Create && req.Operation != admission.Update {
+		return allow()
+	}
+	if req.Operation == admission.Update {
+		// The UUID is immutable, whatever else the update changes
+		if req.Kind.Kind == networkServiceKind || req.Kind.Kind == networkServiceEndpointKind {
+			id, err := specUUID(req.Object.Raw)
+			if err != nil {
+				return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+			}
+			if errs := validateUUID(id, previousUUID(req)); len(errs) > 0 {
+				return deny(http.StatusUnprocessableEntity, "%s %s is invalid: %s", req.Kind.Kind, req.Name, strings.Join(errs, "; "))
+			}
+		}
+		if deleting, err := beingDeleted(req); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		} else if deleting {
+			return allow()
+		}
+		if changed, err := specChanged(req); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		} else if !changed {
+			return allow()
+		}
+	}
+
+	var errs []string
+	switch req.Kind.Kind {
+	case networkServiceKind:
+		ns := &v1.NetworkService{}
+		if err := json.Unmarshal(req.Object.Raw, ns); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		if ns.Namespace == "" {
+			ns.Namespace = req.Namespace
+		}
+		errs = r.validateNetworkService(ns)
+	case networkServiceChannelKind:
+		nsc := &v1.NetworkServiceChannel{}
+		if err := json.Unmarshal(req.Object.Raw, nsc); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		errs = validateNetworkServiceChannel(nsc)
+	case networkServiceEndpointKind:
+		nse := &v1.NetworkServiceEndpoint{}
+		if err := json.Unmarshal(req.Object.Raw, nse); err != nil {
+			return deny(http.StatusBadRequest, "error decoding %s: %s", req.Kind.Kind, err)
+		}
+		errs = validateNetworkServiceEndpoint(nse)
+	default:
+		return allow()
+	}
+	if len(errs) > 0 {
+		return deny(http.StatusUnprocessableEntity, "%s %s is invalid: %s", req.Kind.Kind, req.Name, strings.Join(errs, "; "))
+	}
+	return allow()
+}
+
+// beingDeleted returns true if the object of an update has a deletion
+// timestamp.
+func beingDeleted(req *admission.AdmissionRequest) (bool, error) {
+	obj := struct {
+		meta.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return false, err
+	}
+	return obj.DeletionTimestamp != nil, nil
+}
+
+// specChanged returns true if an update changes the spec of the object. The
+// specs are compared once decoded, as the old and the new object are not
+// necessarily encoded the same way.
+func specChanged(req *admission.AdmissionRequest) (bool, error) {
+	if len(req.OldObject.Raw) == 0 {
+		return true, nil
+	}
+	var specs [2]struct {
+		Spec interface{} `json:"spec"`
+	}
+	for i, raw := range [][]byte{req.OldObject.Raw, req.Object.Raw} {
+		if err := json.Unmarshal(raw, &specs[i]); err != nil {
+			return false, err
+		}
+	}
+	return !reflect.DeepEqual(specs[0].Spec, specs[1].Spec), nil
+}
+
+// validateNetworkService checks the channels, the selectors and the prefixes
+// of a NetworkService. The payload requested from a channel by the service
+// must be one the channel can carry. Overlaps with the prefixes of other
+// services are reported in the status of the service.
+func (r *Reviewer) validateNetworkService(ns *v1.NetworkService) []string {
+	var errs []string
+
+	seen := make(map[string]bool)
+	for _, channel := range ns.Spec.Channels {
+		if channel == nil {
+			continue
+		}
+		if seen[channel.Name] {
+			errs = append(errs, fmt.Sprintf("channel %s is listed more than once", channel.Name))
+			continue
+		}
+		seen[channel.Name] = true
+
+		// The payload of the service, if any, is the payload its clients
+		// request from the channel
+		requested, err := channel.PayloadType()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("channel %s: %s", channel.Name, err))
+			continue
+		}
+
+		if r.Channels == nil {
+			continue
+		}
+		nsc, err := r.Channels.GetNetworkServiceChannel(ns.Namespace, channel.Name)
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("channel %s does not exist in namespace %s", channel.Name, ns.Namespace))
+		} else if err != nil {
+			errs = append(errs, fmt.Sprintf("error looking up channel %s: %s", channel.Name, err))
+		} else if _, err = nsc.Spec.Adapt(requested); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if _, err := selector.ForService(&ns.Spec); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if ns.Spec.ExportTo != nil {
+		if _, err := selector.Requirements(ns.Spec.ExportTo.NamespaceSelector); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid namespace selector: %s", err))
+		}
+	}
+	if _, err := ipam.ParsePrefixes(ns.Spec.Prefixes); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	return errs
+}
+
+// validateNetworkServiceChannel checks the payload of a
+// NetworkServiceChannel and its VLAN ID.
+func validateNetworkServiceChannel(nsc *v1.NetworkServiceChannel) []string {
+	if err := nsc.Spec.Validate(); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// validateNetworkServiceEndpoint checks that the name in the spec of a
+// NetworkServiceEndpoint is the name of the object, and the location and the
+// mechanisms of the endpoint.
+func validateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) []string {
+	var errs []string
+	if nse.Name != "" && nse.Spec.Name != nse.Name {
+		errs = append(errs, fmt.Sprintf("spec.name %q does not match metadata.name %q", nse.Spec.Name, nse.Name))
+	}
+	if nse.Spec.Node != "" {
+		for _, err := range validation.IsDNS1123Subdomain(nse.Spec.Node) {
+			errs = append(errs, fmt.Sprintf("invalid spec.node %q: %s", nse.Spec.Node, err))
+		}
+	}
+	if nse.Spec.Pod != nil && nse.Spec.Pod.Name == "" {
+		errs = append(errs, "spec.pod.name is required")
+	}
+	if nse.Spec.Socket != "" && !path.IsAbs(nse.Spec.Socket) {
+		errs = append(errs, fmt.Sprintf("spec.socket %q is not an absolute path", nse.Spec.Socket))
+	}
+	errs = append(errs, validateMechanisms("spec.mechanisms", nse.Spec.Mechanisms, func(name string) error {
+		_, err := netmesh.ParseLocalMechanismType(name)
+		return err
+	})...)
+	errs = append(errs, validateMechanisms("spec.remoteMechanisms", nse.Spec.RemoteMechanisms, func(name string) error {
+		_, err := netmesh.ParseRemoteMechanismType(name)
+		return err
+	})...)
+	return errs
+}
+
+// validateMechanisms checks that the mechanisms listed in a field are known,
+// according to parse, and listed once.
+func validateMechanisms(field string, mechanisms []string, parse func(name string) error) []string {
+	var errs []string
+	seen := make(map[string]bool)
+	for _, mechanism := range mechanisms {
+		if mechanism == "" {
+			errs = append(errs, fmt.Sprintf("%s must not contain empty mechanisms", field))
+		} else if err := parse(mechanism); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid %s: %s", field, err))
+		} else if seen[mechanism] {
+			errs = append(errs, fmt.Sprintf("mechanism %q is listed more than once in %s", mechanism, field))
+		}
+		seen[mechanism] = true
+	}
+	return errs
+}
+
+// validateUUID checks that the UUID of an updated object did not change, the
+// UUID is immutable once set.
+func validateUUID(id, previous string) []string {
+	if previous != "" && id != previous {
+		return []string{fmt.Sprintf("spec.uuid is immutable, cannot change %s to %q", previous, id)}
+	}
+	return nil
+}
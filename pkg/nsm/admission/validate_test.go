@@ -0,0 +1,105 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+
+	admission "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// noChannels is a ChannelGetter of a namespace without channels.
+type noChannels struct{}
+
+func (noChannels) GetNetworkServiceChannel(namespace, name string) (*v1.NetworkServiceChannel, error) {
+	return nil, apierrors.NewNotFound(v1.Resource(v1.NSMChannelPlural), name)
+}
+
+// serviceWithChannel returns a NetworkService using a channel which does not
+// exist.
+func serviceWithChannel() *v1.NetworkService {
+	return &v1.NetworkService{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-network"},
+		Spec: netmesh.NetworkService{
+			Channels: []*netmesh.NetworkService_NetmeshChannel{{Name: "gold-channel"}},
+		},
+	}
+}
+
+func rawExtension(t *testing.T, obj interface{}) runtime.RawExtension {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("error encoding %v: %s", obj, err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestValidateUpdates(t *testing.T) {
+	now := meta.Now()
+	changed := serviceWithChannel()
+	changed.Spec.Selector = "app=gold"
+	finalized := serviceWithChannel()
+	finalized.Finalizers = []string{"networkservicemesh.io/finalizer"}
+	deleted := serviceWithChannel()
+	deleted.DeletionTimestamp = &now
+	deletedChanged := changed.DeepCopy()
+	deletedChanged.DeletionTimestamp = &now
+
+	tests := []struct {
+		name      string
+		operation admission.Operation
+		old       *v1.NetworkService
+		object    *v1.NetworkService
+		allowed   bool
+	}{
+		{"create", admission.Create, nil, serviceWithChannel(), false},
+		{"update of the spec", admission.Update, serviceWithChannel(), changed, false},
+		{"update of the metadata", admission.Update, finalized, serviceWithChannel(), true},
+		{"update of a deleted object", admission.Update, deleted, deleted, true},
+		{"update of the spec of a deleted object", admission.Update, serviceWithChannel(), deletedChanged, true},
+	}
+
+	reviewer := &Reviewer{Channels: noChannels{}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &admission.AdmissionRequest{
+				Kind:      meta.GroupVersionKind{Group: v1.NSMGroup, Version: v1.NSMGroupVersion, Kind: networkServiceKind},
+				Namespace: test.object.Namespace,
+				Name:      test.object.Name,
+				Operation: test.operation,
+				Object:    rawExtension(t, test.object),
+			}
+			if test.old != nil {
+				req.OldObject = rawExtension(t, test.old)
+			}
+
+			response := reviewer.validate(req)
+			if response.Allowed != test.allowed {
+				message := ""
+				if response.Result != nil {
+					message = response.Result.Message
+				}
+				t.Errorf("expected allowed to be %t, got %t: %s", test.allowed, response.Allowed, message)
+			}
+		})
+	}
+}
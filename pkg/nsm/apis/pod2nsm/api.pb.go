@@ -6,6 +6,7 @@ package pod2nsm
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import netmesh "github.com/ligato/networkservicemesh/netmesh/model/netmesh"
 
 import (
 	context "golang.org/x/net/context"
@@ -34,7 +35,7 @@ func (m *DiscoverServiceRequest) Reset()         { *m = DiscoverServiceRequest{}
 func (m *DiscoverServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*DiscoverServiceRequest) ProtoMessage()    {}
 func (*DiscoverServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{0}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{0}
 }
 func (m *DiscoverServiceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiscoverServiceRequest.Unmarshal(m, b)
@@ -72,7 +73,7 @@ func (m *ServiceDiscoveryResponse) Reset()         { *m = ServiceDiscoveryRespon
 func (m *ServiceDiscoveryResponse) String() string { return proto.CompactTextString(m) }
 func (*ServiceDiscoveryResponse) ProtoMessage()    {}
 func (*ServiceDiscoveryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{1}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{1}
 }
 func (m *ServiceDiscoveryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServiceDiscoveryResponse.Unmarshal(m, b)
@@ -99,18 +100,37 @@ func (m *ServiceDiscoveryResponse) GetServiceIds() []string {
 	return nil
 }
 
+// PublishServiceRequest publishes a pod as an endpoint, i.e. creates a
+// NetworkServiceEndpoint with the labels of the request on behalf of the pod.
+// The labels are advertised by the endpoint as well.
+// The endpoint is owned by the pod and deleted along with it.
 type PublishServiceRequest struct {
-	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// name of the endpoint, generated from the name of the pod when empty
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// namespace and name of the pod publishing the endpoint, the endpoint is
+	// created in the namespace of the pod
+	PodNamespace string `protobuf:"bytes,3,opt,name=pod_namespace,json=podNamespace" json:"pod_namespace,omitempty"`
+	PodName      string `protobuf:"bytes,4,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	// socket is the path of the NSM socket of the workspace of the pod
+	Socket string `protobuf:"bytes,5,opt,name=socket" json:"socket,omitempty"`
+	// mechanisms supported by the endpoint, in order of preference
+	Mechanisms []string `protobuf:"bytes,6,rep,name=mechanisms" json:"mechanisms,omitempty"`
+	// capacity is the maximum number of connections, 0 for unlimited
+	Capacity uint32 `protobuf:"varint,7,opt,name=capacity" json:"capacity,omitempty"`
+	// remote_mechanisms carrying connections from clients on other nodes
+	// supported by the endpoint, in order of preference
+	RemoteMechanisms     []string `protobuf:"bytes,8,rep,name=remote_mechanisms,json=remoteMechanisms" json:"remote_mechanisms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PublishServiceRequest) Reset()         { *m = PublishServiceRequest{} }
 func (m *PublishServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*PublishServiceRequest) ProtoMessage()    {}
 func (*PublishServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{2}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{2}
 }
 func (m *PublishServiceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishServiceRequest.Unmarshal(m, b)
@@ -137,8 +157,57 @@ func (m *PublishServiceRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (m *PublishServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PublishServiceRequest) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *PublishServiceRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *PublishServiceRequest) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *PublishServiceRequest) GetMechanisms() []string {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *PublishServiceRequest) GetCapacity() uint32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *PublishServiceRequest) GetRemoteMechanisms() []string {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
 type PublishServiceResponse struct {
-	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
+	EndpointId           string   `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,7 +217,7 @@ func (m *PublishServiceResponse) Reset()         { *m = PublishServiceResponse{}
 func (m *PublishServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*PublishServiceResponse) ProtoMessage()    {}
 func (*PublishServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{3}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{3}
 }
 func (m *PublishServiceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishServiceResponse.Unmarshal(m, b)
@@ -168,15 +237,18 @@ func (m *PublishServiceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PublishServiceResponse proto.InternalMessageInfo
 
-func (m *PublishServiceResponse) GetServiceId() string {
+func (m *PublishServiceResponse) GetEndpointId() string {
 	if m != nil {
-		return m.ServiceId
+		return m.EndpointId
 	}
 	return ""
 }
 
 type DelistServiceRequest struct {
-	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
+	EndpointId string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
+	// namespace and name of the pod owning the endpoint
+	PodNamespace         string   `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace" json:"pod_namespace,omitempty"`
+	PodName              string   `protobuf:"bytes,3,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -186,7 +258,7 @@ func (m *DelistServiceRequest) Reset()         { *m = DelistServiceRequest{} }
 func (m *DelistServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*DelistServiceRequest) ProtoMessage()    {}
 func (*DelistServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{4}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{4}
 }
 func (m *DelistServiceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelistServiceRequest.Unmarshal(m, b)
@@ -206,9 +278,23 @@ func (m *DelistServiceRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DelistServiceRequest proto.InternalMessageInfo
 
-func (m *DelistServiceRequest) GetServiceId() string {
+func (m *DelistServiceRequest) GetEndpointId() string {
 	if m != nil {
-		return m.ServiceId
+		return m.EndpointId
+	}
+	return ""
+}
+
+func (m *DelistServiceRequest) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *DelistServiceRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
 	}
 	return ""
 }
@@ -223,7 +309,7 @@ func (m *DelistServiceResponse) Reset()         { *m = DelistServiceResponse{} }
 func (m *DelistServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*DelistServiceResponse) ProtoMessage()    {}
 func (*DelistServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{5}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{5}
 }
 func (m *DelistServiceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelistServiceResponse.Unmarshal(m, b)
@@ -243,6 +329,373 @@ func (m *DelistServiceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DelistServiceResponse proto.InternalMessageInfo
 
+// HeartbeatRequest renews the heartbeat of an endpoint. An endpoint which
+// sent a heartbeat is marked unhealthy and no longer selected when it does not
+// renew it within the grace period.
+type HeartbeatRequest struct {
+	EndpointId string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
+	// namespace and name of the pod owning the endpoint
+	PodNamespace         string   `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace" json:"pod_namespace,omitempty"`
+	PodName              string   `protobuf:"bytes,3,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatRequest) Reset()         { *m = HeartbeatRequest{} }
+func (m *HeartbeatRequest) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatRequest) ProtoMessage()    {}
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{6}
+}
+func (m *HeartbeatRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatRequest.Unmarshal(m, b)
+}
+func (m *HeartbeatRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatRequest.Marshal(b, m, deterministic)
+}
+func (dst *HeartbeatRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatRequest.Merge(dst, src)
+}
+func (m *HeartbeatRequest) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatRequest.Size(m)
+}
+func (m *HeartbeatRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatRequest proto.InternalMessageInfo
+
+func (m *HeartbeatRequest) GetEndpointId() string {
+	if m != nil {
+		return m.EndpointId
+	}
+	return ""
+}
+
+func (m *HeartbeatRequest) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *HeartbeatRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+type HeartbeatResponse struct {
+	// grace_period_seconds is the time after its last heartbeat the
+	// endpoint is marked unhealthy
+	GracePeriodSeconds   int64    `protobuf:"varint,1,opt,name=grace_period_seconds,json=gracePeriodSeconds" json:"grace_period_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatResponse) Reset()         { *m = HeartbeatResponse{} }
+func (m *HeartbeatResponse) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatResponse) ProtoMessage()    {}
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{7}
+}
+func (m *HeartbeatResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatResponse.Unmarshal(m, b)
+}
+func (m *HeartbeatResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatResponse.Marshal(b, m, deterministic)
+}
+func (dst *HeartbeatResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatResponse.Merge(dst, src)
+}
+func (m *HeartbeatResponse) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatResponse.Size(m)
+}
+func (m *HeartbeatResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatResponse proto.InternalMessageInfo
+
+func (m *HeartbeatResponse) GetGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.GracePeriodSeconds
+	}
+	return 0
+}
+
+type GetServiceRequest struct {
+	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServiceRequest) Reset()         { *m = GetServiceRequest{} }
+func (m *GetServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServiceRequest) ProtoMessage()    {}
+func (*GetServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{8}
+}
+func (m *GetServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceRequest.Unmarshal(m, b)
+}
+func (m *GetServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceRequest.Merge(dst, src)
+}
+func (m *GetServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServiceRequest.Size(m)
+}
+func (m *GetServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceRequest proto.InternalMessageInfo
+
+func (m *GetServiceRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+type GetServiceResponse struct {
+	ServiceId string            `protobuf:"bytes,1,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
+	Name      string            `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Namespace string            `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	Labels    map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// endpoint_ids are the UUIDs of the endpoints selected by the service
+	EndpointIds          []string `protobuf:"bytes,5,rep,name=endpoint_ids,json=endpointIds" json:"endpoint_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServiceResponse) Reset()         { *m = GetServiceResponse{} }
+func (m *GetServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServiceResponse) ProtoMessage()    {}
+func (*GetServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{9}
+}
+func (m *GetServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceResponse.Unmarshal(m, b)
+}
+func (m *GetServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceResponse.Merge(dst, src)
+}
+func (m *GetServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetServiceResponse.Size(m)
+}
+func (m *GetServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceResponse proto.InternalMessageInfo
+
+func (m *GetServiceResponse) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GetServiceResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetServiceResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetServiceResponse) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *GetServiceResponse) GetEndpointIds() []string {
+	if m != nil {
+		return m.EndpointIds
+	}
+	return nil
+}
+
+type GetEndpointRequest struct {
+	EndpointId           string   `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEndpointRequest) Reset()         { *m = GetEndpointRequest{} }
+func (m *GetEndpointRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEndpointRequest) ProtoMessage()    {}
+func (*GetEndpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{10}
+}
+func (m *GetEndpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEndpointRequest.Unmarshal(m, b)
+}
+func (m *GetEndpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEndpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetEndpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEndpointRequest.Merge(dst, src)
+}
+func (m *GetEndpointRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEndpointRequest.Size(m)
+}
+func (m *GetEndpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEndpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEndpointRequest proto.InternalMessageInfo
+
+func (m *GetEndpointRequest) GetEndpointId() string {
+	if m != nil {
+		return m.EndpointId
+	}
+	return ""
+}
+
+type GetEndpointResponse struct {
+	EndpointId string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	// labels of the endpoint object, which services select along with the
+	// advertised labels
+	Labels  map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Node    string            `protobuf:"bytes,5,opt,name=node" json:"node,omitempty"`
+	PodName string            `protobuf:"bytes,6,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	Socket  string            `protobuf:"bytes,7,opt,name=socket" json:"socket,omitempty"`
+	// advertised_labels are the labels the endpoint advertises to its
+	// clients
+	AdvertisedLabels     map[string]string `protobuf:"bytes,8,rep,name=advertised_labels,json=advertisedLabels" json:"advertised_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Mechanisms           []string          `protobuf:"bytes,9,rep,name=mechanisms" json:"mechanisms,omitempty"`
+	Capacity             uint32            `protobuf:"varint,10,opt,name=capacity" json:"capacity,omitempty"`
+	RemoteMechanisms     []string          `protobuf:"bytes,11,rep,name=remote_mechanisms,json=remoteMechanisms" json:"remote_mechanisms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetEndpointResponse) Reset()         { *m = GetEndpointResponse{} }
+func (m *GetEndpointResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEndpointResponse) ProtoMessage()    {}
+func (*GetEndpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{11}
+}
+func (m *GetEndpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEndpointResponse.Unmarshal(m, b)
+}
+func (m *GetEndpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEndpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetEndpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEndpointResponse.Merge(dst, src)
+}
+func (m *GetEndpointResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEndpointResponse.Size(m)
+}
+func (m *GetEndpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEndpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEndpointResponse proto.InternalMessageInfo
+
+func (m *GetEndpointResponse) GetEndpointId() string {
+	if m != nil {
+		return m.EndpointId
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *GetEndpointResponse) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *GetEndpointResponse) GetAdvertisedLabels() map[string]string {
+	if m != nil {
+		return m.AdvertisedLabels
+	}
+	return nil
+}
+
+func (m *GetEndpointResponse) GetMechanisms() []string {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *GetEndpointResponse) GetCapacity() uint32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *GetEndpointResponse) GetRemoteMechanisms() []string {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
 type ExposeChannelRequest struct {
 	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -254,7 +707,7 @@ func (m *ExposeChannelRequest) Reset()         { *m = ExposeChannelRequest{} }
 func (m *ExposeChannelRequest) String() string { return proto.CompactTextString(m) }
 func (*ExposeChannelRequest) ProtoMessage()    {}
 func (*ExposeChannelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{6}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{12}
 }
 func (m *ExposeChannelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExposeChannelRequest.Unmarshal(m, b)
@@ -292,7 +745,7 @@ func (m *ExposeChannelResponse) Reset()         { *m = ExposeChannelResponse{} }
 func (m *ExposeChannelResponse) String() string { return proto.CompactTextString(m) }
 func (*ExposeChannelResponse) ProtoMessage()    {}
 func (*ExposeChannelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{7}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{13}
 }
 func (m *ExposeChannelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExposeChannelResponse.Unmarshal(m, b)
@@ -330,7 +783,7 @@ func (m *ConcealChannelRequest) Reset()         { *m = ConcealChannelRequest{} }
 func (m *ConcealChannelRequest) String() string { return proto.CompactTextString(m) }
 func (*ConcealChannelRequest) ProtoMessage()    {}
 func (*ConcealChannelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{8}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{14}
 }
 func (m *ConcealChannelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConcealChannelRequest.Unmarshal(m, b)
@@ -367,7 +820,7 @@ func (m *ConcealChannelResponse) Reset()         { *m = ConcealChannelResponse{}
 func (m *ConcealChannelResponse) String() string { return proto.CompactTextString(m) }
 func (*ConcealChannelResponse) ProtoMessage()    {}
 func (*ConcealChannelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{9}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{15}
 }
 func (m *ConcealChannelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConcealChannelResponse.Unmarshal(m, b)
@@ -387,18 +840,36 @@ func (m *ConcealChannelResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ConcealChannelResponse proto.InternalMessageInfo
 
+// CreateConnectionRequest connects a pod to an endpoint of a service, i.e.
+// creates a NetworkServiceConnection with the labels of the request on behalf
+// of the pod. The connection is owned by the pod and deleted along with it.
 type CreateConnectionRequest struct {
-	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// namespace and name of the client pod, the service must be in the
+	// namespace of the pod
+	PodNamespace string `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace" json:"pod_namespace,omitempty"`
+	PodName      string `protobuf:"bytes,3,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	ServiceId    string `protobuf:"bytes,4,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
+	// channel of the service carrying the connection, may be empty for
+	// services with a single channel
+	Channel string `protobuf:"bytes,5,opt,name=channel" json:"channel,omitempty"`
+	// payload requested by the client, any payload when empty
+	Payload string `protobuf:"bytes,6,opt,name=payload" json:"payload,omitempty"`
+	// mechanisms accepted by the client, in order of preference
+	Mechanisms []*netmesh.LocalMechanism `protobuf:"bytes,7,rep,name=mechanisms" json:"mechanisms,omitempty"`
+	// remote_mechanisms accepted by the client to carry the connection to an
+	// endpoint on another node, in order of preference
+	RemoteMechanisms     []*netmesh.RemoteMechanism `protobuf:"bytes,8,rep,name=remote_mechanisms,json=remoteMechanisms" json:"remote_mechanisms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *CreateConnectionRequest) Reset()         { *m = CreateConnectionRequest{} }
 func (m *CreateConnectionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateConnectionRequest) ProtoMessage()    {}
 func (*CreateConnectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{10}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{16}
 }
 func (m *CreateConnectionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateConnectionRequest.Unmarshal(m, b)
@@ -425,18 +896,79 @@ func (m *CreateConnectionRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (m *CreateConnectionRequest) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *CreateConnectionRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *CreateConnectionRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *CreateConnectionRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *CreateConnectionRequest) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *CreateConnectionRequest) GetMechanisms() []*netmesh.LocalMechanism {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *CreateConnectionRequest) GetRemoteMechanisms() []*netmesh.RemoteMechanism {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
 type CreateConnectionResponse struct {
-	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId" json:"connection_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ConnectionId string `protobuf:"bytes,1,opt,name=connection_id,json=connectionId" json:"connection_id,omitempty"`
+	// endpoint_id is the UUID of the endpoint selected for the connection
+	EndpointId string `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId" json:"endpoint_id,omitempty"`
+	Channel    string `protobuf:"bytes,3,opt,name=channel" json:"channel,omitempty"`
+	// mechanism plugging the connection into the client, among the
+	// mechanisms of the request
+	Mechanism *netmesh.LocalMechanism `protobuf:"bytes,4,opt,name=mechanism" json:"mechanism,omitempty"`
+	// socket is the path of the NSM socket of the workspace of the endpoint
+	Socket string `protobuf:"bytes,5,opt,name=socket" json:"socket,omitempty"`
+	// remote_mechanism carrying the connection to the node of the endpoint,
+	// among the remote mechanisms of the request, unset when the client and
+	// the endpoint run on the same node
+	RemoteMechanism      *netmesh.RemoteMechanism `protobuf:"bytes,6,opt,name=remote_mechanism,json=remoteMechanism" json:"remote_mechanism,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *CreateConnectionResponse) Reset()         { *m = CreateConnectionResponse{} }
 func (m *CreateConnectionResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateConnectionResponse) ProtoMessage()    {}
 func (*CreateConnectionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{11}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{17}
 }
 func (m *CreateConnectionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateConnectionResponse.Unmarshal(m, b)
@@ -463,8 +995,46 @@ func (m *CreateConnectionResponse) GetConnectionId() string {
 	return ""
 }
 
+func (m *CreateConnectionResponse) GetEndpointId() string {
+	if m != nil {
+		return m.EndpointId
+	}
+	return ""
+}
+
+func (m *CreateConnectionResponse) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *CreateConnectionResponse) GetMechanism() *netmesh.LocalMechanism {
+	if m != nil {
+		return m.Mechanism
+	}
+	return nil
+}
+
+func (m *CreateConnectionResponse) GetSocket() string {
+	if m != nil {
+		return m.Socket
+	}
+	return ""
+}
+
+func (m *CreateConnectionResponse) GetRemoteMechanism() *netmesh.RemoteMechanism {
+	if m != nil {
+		return m.RemoteMechanism
+	}
+	return nil
+}
+
 type DestroyConnectionRequest struct {
-	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId" json:"connection_id,omitempty"`
+	ConnectionId string `protobuf:"bytes,1,opt,name=connection_id,json=connectionId" json:"connection_id,omitempty"`
+	// namespace and name of the pod owning the connection
+	PodNamespace         string   `protobuf:"bytes,2,opt,name=pod_namespace,json=podNamespace" json:"pod_namespace,omitempty"`
+	PodName              string   `protobuf:"bytes,3,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -474,7 +1044,7 @@ func (m *DestroyConnectionRequest) Reset()         { *m = DestroyConnectionReque
 func (m *DestroyConnectionRequest) String() string { return proto.CompactTextString(m) }
 func (*DestroyConnectionRequest) ProtoMessage()    {}
 func (*DestroyConnectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{12}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{18}
 }
 func (m *DestroyConnectionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DestroyConnectionRequest.Unmarshal(m, b)
@@ -501,6 +1071,20 @@ func (m *DestroyConnectionRequest) GetConnectionId() string {
 	return ""
 }
 
+func (m *DestroyConnectionRequest) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *DestroyConnectionRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
 type DestroyConnectionResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -511,7 +1095,7 @@ func (m *DestroyConnectionResponse) Reset()         { *m = DestroyConnectionResp
 func (m *DestroyConnectionResponse) String() string { return proto.CompactTextString(m) }
 func (*DestroyConnectionResponse) ProtoMessage()    {}
 func (*DestroyConnectionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_82112140657194a2, []int{13}
+	return fileDescriptor_api_8b8ad13795dd2e9d, []int{19}
 }
 func (m *DestroyConnectionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DestroyConnectionResponse.Unmarshal(m, b)
@@ -540,6 +1124,15 @@ func init() {
 	proto.RegisterType((*PublishServiceResponse)(nil), "pod2nsm.PublishServiceResponse")
 	proto.RegisterType((*DelistServiceRequest)(nil), "pod2nsm.DelistServiceRequest")
 	proto.RegisterType((*DelistServiceResponse)(nil), "pod2nsm.DelistServiceResponse")
+	proto.RegisterType((*HeartbeatRequest)(nil), "pod2nsm.HeartbeatRequest")
+	proto.RegisterType((*HeartbeatResponse)(nil), "pod2nsm.HeartbeatResponse")
+	proto.RegisterType((*GetServiceRequest)(nil), "pod2nsm.GetServiceRequest")
+	proto.RegisterType((*GetServiceResponse)(nil), "pod2nsm.GetServiceResponse")
+	proto.RegisterMapType((map[string]string)(nil), "pod2nsm.GetServiceResponse.LabelsEntry")
+	proto.RegisterType((*GetEndpointRequest)(nil), "pod2nsm.GetEndpointRequest")
+	proto.RegisterType((*GetEndpointResponse)(nil), "pod2nsm.GetEndpointResponse")
+	proto.RegisterMapType((map[string]string)(nil), "pod2nsm.GetEndpointResponse.AdvertisedLabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "pod2nsm.GetEndpointResponse.LabelsEntry")
 	proto.RegisterType((*ExposeChannelRequest)(nil), "pod2nsm.ExposeChannelRequest")
 	proto.RegisterMapType((map[string]string)(nil), "pod2nsm.ExposeChannelRequest.LabelsEntry")
 	proto.RegisterType((*ExposeChannelResponse)(nil), "pod2nsm.ExposeChannelResponse")
@@ -560,13 +1153,15 @@ var _ grpc.ClientConn
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
-// NetworkServicesClient is the client API for NetworkServices service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+// Client API for NetworkServices service
+
 type NetworkServicesClient interface {
 	DiscoverService(ctx context.Context, in *DiscoverServiceRequest, opts ...grpc.CallOption) (*ServiceDiscoveryResponse, error)
 	PublishService(ctx context.Context, in *PublishServiceRequest, opts ...grpc.CallOption) (*PublishServiceResponse, error)
 	DelistService(ctx context.Context, in *DelistServiceRequest, opts ...grpc.CallOption) (*DelistServiceResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error)
+	GetEndpoint(ctx context.Context, in *GetEndpointRequest, opts ...grpc.CallOption) (*GetEndpointResponse, error)
 	ExposeChannel(ctx context.Context, in *ExposeChannelRequest, opts ...grpc.CallOption) (*ExposeChannelResponse, error)
 	ConcealChannel(ctx context.Context, in *ConcealChannelRequest, opts ...grpc.CallOption) (*ConcealChannelResponse, error)
 	CreateConnection(ctx context.Context, in *CreateConnectionRequest, opts ...grpc.CallOption) (*CreateConnectionResponse, error)
@@ -583,7 +1178,7 @@ func NewNetworkServicesClient(cc *grpc.ClientConn) NetworkServicesClient {
 
 func (c *networkServicesClient) DiscoverService(ctx context.Context, in *DiscoverServiceRequest, opts ...grpc.CallOption) (*ServiceDiscoveryResponse, error) {
 	out := new(ServiceDiscoveryResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/DiscoverService", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/DiscoverService", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -592,7 +1187,7 @@ func (c *networkServicesClient) DiscoverService(ctx context.Context, in *Discove
 
 func (c *networkServicesClient) PublishService(ctx context.Context, in *PublishServiceRequest, opts ...grpc.CallOption) (*PublishServiceResponse, error) {
 	out := new(PublishServiceResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/PublishService", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/PublishService", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -601,7 +1196,34 @@ func (c *networkServicesClient) PublishService(ctx context.Context, in *PublishS
 
 func (c *networkServicesClient) DelistService(ctx context.Context, in *DelistServiceRequest, opts ...grpc.CallOption) (*DelistServiceResponse, error) {
 	out := new(DelistServiceResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/DelistService", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/DelistService", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServicesClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/Heartbeat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServicesClient) GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error) {
+	out := new(GetServiceResponse)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/GetService", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServicesClient) GetEndpoint(ctx context.Context, in *GetEndpointRequest, opts ...grpc.CallOption) (*GetEndpointResponse, error) {
+	out := new(GetEndpointResponse)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/GetEndpoint", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -610,7 +1232,7 @@ func (c *networkServicesClient) DelistService(ctx context.Context, in *DelistSer
 
 func (c *networkServicesClient) ExposeChannel(ctx context.Context, in *ExposeChannelRequest, opts ...grpc.CallOption) (*ExposeChannelResponse, error) {
 	out := new(ExposeChannelResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/ExposeChannel", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/ExposeChannel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -619,7 +1241,7 @@ func (c *networkServicesClient) ExposeChannel(ctx context.Context, in *ExposeCha
 
 func (c *networkServicesClient) ConcealChannel(ctx context.Context, in *ConcealChannelRequest, opts ...grpc.CallOption) (*ConcealChannelResponse, error) {
 	out := new(ConcealChannelResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/ConcealChannel", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/ConcealChannel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -628,7 +1250,7 @@ func (c *networkServicesClient) ConcealChannel(ctx context.Context, in *ConcealC
 
 func (c *networkServicesClient) CreateConnection(ctx context.Context, in *CreateConnectionRequest, opts ...grpc.CallOption) (*CreateConnectionResponse, error) {
 	out := new(CreateConnectionResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/CreateConnection", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/CreateConnection", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -637,18 +1259,22 @@ func (c *networkServicesClient) CreateConnection(ctx context.Context, in *Create
 
 func (c *networkServicesClient) DestroyConnection(ctx context.Context, in *DestroyConnectionRequest, opts ...grpc.CallOption) (*DestroyConnectionResponse, error) {
 	out := new(DestroyConnectionResponse)
-	err := c.cc.Invoke(ctx, "/pod2nsm.NetworkServices/DestroyConnection", in, out, opts...)
+	err := grpc.Invoke(ctx, "/pod2nsm.NetworkServices/DestroyConnection", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// NetworkServicesServer is the server API for NetworkServices service.
+// Server API for NetworkServices service
+
 type NetworkServicesServer interface {
 	DiscoverService(context.Context, *DiscoverServiceRequest) (*ServiceDiscoveryResponse, error)
 	PublishService(context.Context, *PublishServiceRequest) (*PublishServiceResponse, error)
 	DelistService(context.Context, *DelistServiceRequest) (*DelistServiceResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	GetService(context.Context, *GetServiceRequest) (*GetServiceResponse, error)
+	GetEndpoint(context.Context, *GetEndpointRequest) (*GetEndpointResponse, error)
 	ExposeChannel(context.Context, *ExposeChannelRequest) (*ExposeChannelResponse, error)
 	ConcealChannel(context.Context, *ConcealChannelRequest) (*ConcealChannelResponse, error)
 	CreateConnection(context.Context, *CreateConnectionRequest) (*CreateConnectionResponse, error)
@@ -713,6 +1339,60 @@ func _NetworkServices_DelistService_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServices_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServicesServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pod2nsm.NetworkServices/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServicesServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServices_GetService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServicesServer).GetService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pod2nsm.NetworkServices/GetService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServicesServer).GetService(ctx, req.(*GetServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServices_GetEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServicesServer).GetEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pod2nsm.NetworkServices/GetEndpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServicesServer).GetEndpoint(ctx, req.(*GetEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServices_ExposeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExposeChannelRequest)
 	if err := dec(in); err != nil {
@@ -801,6 +1481,18 @@ var _NetworkServices_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DelistService",
 			Handler:    _NetworkServices_DelistService_Handler,
 		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _NetworkServices_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetService",
+			Handler:    _NetworkServices_GetService_Handler,
+		},
+		{
+			MethodName: "GetEndpoint",
+			Handler:    _NetworkServices_GetEndpoint_Handler,
+		},
 		{
 			MethodName: "ExposeChannel",
 			Handler:    _NetworkServices_ExposeChannel_Handler,
@@ -822,41 +1514,74 @@ var _NetworkServices_serviceDesc = grpc.ServiceDesc{
 	Metadata: "api.proto",
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_api_82112140657194a2) }
-
-var fileDescriptor_api_82112140657194a2 = []byte{
-	// 516 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x5b, 0x28, 0xca, 0x84, 0x7e, 0xb0, 0x4a, 0x52, 0x63, 0xd4, 0x26, 0x35, 0x97, 0xf2,
-	0xa1, 0x1c, 0x82, 0xf8, 0x3e, 0x20, 0x70, 0x7a, 0x88, 0x84, 0x2a, 0x6a, 0x84, 0x90, 0xb8, 0x20,
-	0xc7, 0x1e, 0xa9, 0x56, 0xcd, 0xae, 0xf1, 0x6e, 0x02, 0xf9, 0x1f, 0x08, 0x21, 0xfe, 0x1e, 0x7f,
-	0x04, 0x25, 0xde, 0xd8, 0x9b, 0xcd, 0x3a, 0xe9, 0x21, 0xb7, 0x64, 0x76, 0xde, 0x9b, 0x7d, 0x2f,
-	0x6f, 0x36, 0x50, 0x0b, 0xd2, 0xb8, 0x9b, 0x66, 0x4c, 0x30, 0x72, 0x2b, 0x65, 0x51, 0x8f, 0xf2,
-	0x6f, 0xee, 0x1f, 0x0b, 0x5a, 0xfd, 0x98, 0x87, 0x6c, 0x8c, 0xd9, 0x47, 0xcc, 0xc6, 0x71, 0x88,
-	0x3e, 0x7e, 0x1f, 0x21, 0x17, 0xc4, 0x83, 0x9d, 0x24, 0x18, 0x62, 0xc2, 0x6d, 0xab, 0xb3, 0x7d,
-	0x5a, 0xef, 0x3d, 0xea, 0x4a, 0x50, 0xd7, 0x0c, 0xe8, 0xbe, 0x9f, 0x75, 0x9f, 0x51, 0x91, 0x4d,
-	0x7c, 0x09, 0x75, 0x5e, 0x42, 0x5d, 0x29, 0x93, 0x03, 0xd8, 0xbe, 0xc2, 0x89, 0x6d, 0x75, 0xac,
-	0xd3, 0x9a, 0x3f, 0xfd, 0x48, 0x1a, 0x70, 0x73, 0x1c, 0x24, 0x23, 0xb4, 0xb7, 0x66, 0xb5, 0xfc,
-	0xcb, 0xab, 0xad, 0x17, 0x96, 0xfb, 0x1a, 0x6c, 0x39, 0x60, 0x3e, 0x6f, 0xe2, 0x23, 0x4f, 0x19,
-	0xe5, 0x48, 0xda, 0x50, 0xe7, 0xf9, 0xd9, 0xd7, 0x38, 0xca, 0x2f, 0x58, 0xf3, 0x41, 0x96, 0x06,
-	0x11, 0x77, 0x7f, 0x5b, 0xd0, 0xfc, 0x30, 0x1a, 0x26, 0x31, 0xbf, 0xd4, 0x64, 0xbd, 0xd3, 0x64,
-	0x3d, 0x2c, 0x64, 0x19, 0xfb, 0x37, 0xad, 0xea, 0x39, 0xb4, 0xf4, 0x39, 0x52, 0xd3, 0x11, 0x40,
-	0xa9, 0x49, 0x92, 0xd5, 0x0a, 0x49, 0xee, 0x53, 0x68, 0xf4, 0x31, 0x89, 0xb9, 0xd0, 0xf4, 0xac,
-	0x81, 0x1d, 0x42, 0x53, 0x83, 0xe5, 0xe3, 0xdc, 0x5f, 0x16, 0x34, 0xce, 0x7e, 0xa6, 0x8c, 0xa3,
-	0x77, 0x19, 0x50, 0x8a, 0xc9, 0x9c, 0xf0, 0xad, 0x66, 0xd0, 0x83, 0xc2, 0x20, 0x53, 0xfb, 0xa6,
-	0xfd, 0x79, 0x06, 0x4d, 0x6d, 0x4c, 0x69, 0x4f, 0x98, 0x97, 0x14, 0x9d, 0xb2, 0x32, 0x88, 0xa6,
-	0x38, 0x8f, 0xd1, 0x10, 0x83, 0x44, 0x93, 0xb3, 0x06, 0x67, 0x43, 0x4b, 0xc7, 0x49, 0x83, 0xfe,
-	0x5a, 0x70, 0xe8, 0x65, 0x18, 0x08, 0xf4, 0x18, 0xa5, 0x18, 0x8a, 0x98, 0xd1, 0x39, 0x69, 0x5f,
-	0xf3, 0xe8, 0x71, 0xe1, 0x51, 0x05, 0x62, 0xd3, 0x36, 0xbd, 0x01, 0x7b, 0x79, 0x92, 0x74, 0xea,
-	0x3e, 0xec, 0x86, 0x45, 0xb5, 0x14, 0x7d, 0xbb, 0x2c, 0x0e, 0xa2, 0x29, 0x41, 0x1f, 0xb9, 0xc8,
-	0xd8, 0x64, 0x59, 0xdd, 0xb5, 0x08, 0xee, 0xc1, 0x5d, 0x03, 0x41, 0x7e, 0x85, 0xde, 0xbf, 0x1b,
-	0xb0, 0x7f, 0x8e, 0xe2, 0x07, 0xcb, 0xae, 0x64, 0xee, 0x38, 0xf9, 0x04, 0xfb, 0xda, 0xc3, 0x41,
-	0xda, 0x6b, 0x9e, 0x14, 0xe7, 0xa4, 0x68, 0xa8, 0x7c, 0x0a, 0x2e, 0x60, 0x6f, 0x71, 0xa1, 0xc8,
-	0xf1, 0xea, 0x8d, 0x76, 0xda, 0x95, 0xe7, 0x92, 0xf2, 0x1c, 0x76, 0x17, 0x76, 0x86, 0x1c, 0x95,
-	0xf7, 0x34, 0xac, 0xa0, 0x73, 0x5c, 0x75, 0x5c, 0xf2, 0x2d, 0x64, 0x5a, 0xe1, 0x33, 0xad, 0x94,
-	0xc2, 0x67, 0x5e, 0x85, 0x0b, 0xd8, 0x5b, 0xcc, 0xac, 0x22, 0xd9, 0xb8, 0x04, 0x8a, 0x64, 0x73,
-	0xd8, 0xc9, 0x67, 0x38, 0xd0, 0xf3, 0x44, 0x3a, 0xeb, 0x42, 0xad, 0xfc, 0x3c, 0x95, 0x61, 0xfc,
-	0x02, 0x77, 0x96, 0x62, 0x42, 0x4e, 0x14, 0xc3, 0xcc, 0x19, 0x74, 0xdc, 0x55, 0x2d, 0x39, 0xf7,
-	0x70, 0x67, 0xf6, 0x67, 0xf6, 0xe4, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6c, 0x9d, 0x7b, 0x2c,
-	0xd9, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("api.proto", fileDescriptor_api_8b8ad13795dd2e9d) }
+
+var fileDescriptor_api_8b8ad13795dd2e9d = []byte{
+	// 1056 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4b, 0x6f, 0x23, 0x45,
+	0x10, 0x96, 0x1f, 0xb1, 0xe3, 0x72, 0x42, 0xec, 0x26, 0x8f, 0xc9, 0x64, 0x37, 0x8f, 0xd9, 0x03,
+	0x86, 0x45, 0x5e, 0x64, 0xb4, 0xc0, 0xc2, 0x01, 0x76, 0x1d, 0x8b, 0x44, 0x5a, 0xa2, 0xc5, 0x2b,
+	0x84, 0xc4, 0xc5, 0x6a, 0xcf, 0x94, 0xe2, 0x51, 0xc6, 0xd3, 0xc3, 0xf4, 0x24, 0xc1, 0x27, 0x2e,
+	0x9c, 0x39, 0x71, 0xe0, 0x4f, 0x70, 0x47, 0xe2, 0x27, 0xf1, 0x27, 0x90, 0x7b, 0xda, 0xf3, 0x68,
+	0xcf, 0x8c, 0x03, 0x89, 0x38, 0x79, 0xba, 0xaa, 0xab, 0xba, 0xfa, 0xab, 0xaa, 0xaf, 0xcb, 0xd0,
+	0xa0, 0x9e, 0xdd, 0xf5, 0x7c, 0x16, 0x30, 0x52, 0xf7, 0x98, 0xd5, 0x73, 0xf9, 0x54, 0x3f, 0xbb,
+	0xb4, 0x83, 0xc9, 0xf5, 0xb8, 0x6b, 0xb2, 0xe9, 0x33, 0xc7, 0xbe, 0xa4, 0x01, 0x7b, 0xe6, 0x62,
+	0x70, 0xcb, 0xfc, 0x2b, 0x8e, 0xfe, 0x8d, 0x6d, 0xe2, 0x14, 0xf9, 0x64, 0x2e, 0x12, 0xbf, 0x53,
+	0x66, 0xa1, 0x13, 0xad, 0xe4, 0x6f, 0xe8, 0xd2, 0xf8, 0xbd, 0x04, 0xbb, 0xa7, 0x36, 0x37, 0xd9,
+	0x0d, 0xfa, 0x6f, 0x43, 0xdb, 0x21, 0xfe, 0x78, 0x8d, 0x3c, 0x20, 0x7d, 0xa8, 0x39, 0x74, 0x8c,
+	0x0e, 0xd7, 0x4a, 0xc7, 0x95, 0x4e, 0xb3, 0xf7, 0xb4, 0x2b, 0x8f, 0xef, 0x66, 0x1b, 0x74, 0x5f,
+	0x8b, 0xdd, 0x03, 0x37, 0xf0, 0x67, 0x43, 0x69, 0xaa, 0xbf, 0x80, 0x66, 0x42, 0x4c, 0x5a, 0x50,
+	0xb9, 0xc2, 0x99, 0x56, 0x3a, 0x2e, 0x75, 0x1a, 0xc3, 0xf9, 0x27, 0xd9, 0x86, 0xb5, 0x1b, 0xea,
+	0x5c, 0xa3, 0x56, 0x16, 0xb2, 0x70, 0xf1, 0x79, 0xf9, 0xb3, 0x92, 0xf1, 0x05, 0x68, 0xf2, 0x80,
+	0xc5, 0x79, 0xb3, 0x21, 0x72, 0x8f, 0xb9, 0x1c, 0xc9, 0x11, 0x34, 0xe5, 0x4d, 0x47, 0xb6, 0x15,
+	0x06, 0xd8, 0x18, 0x82, 0x14, 0x9d, 0x5b, 0xdc, 0xf8, 0xbb, 0x0c, 0x3b, 0x6f, 0xae, 0xc7, 0x8e,
+	0xcd, 0x27, 0xca, 0xb5, 0x5e, 0x29, 0xd7, 0xfa, 0x20, 0xba, 0x56, 0xe6, 0xfe, 0xac, 0x5b, 0x11,
+	0x02, 0x55, 0x97, 0x4e, 0x17, 0x31, 0x8b, 0x6f, 0xf2, 0x04, 0x36, 0x3d, 0x66, 0x8d, 0xe6, 0xdf,
+	0xdc, 0xa3, 0x26, 0x6a, 0x15, 0xa1, 0xdc, 0xf0, 0x98, 0x75, 0xb1, 0x90, 0x91, 0x7d, 0x58, 0x5f,
+	0x6c, 0xd2, 0xaa, 0x42, 0x5f, 0x97, 0x7a, 0xb2, 0x0b, 0x35, 0xce, 0xcc, 0x2b, 0x0c, 0xb4, 0x35,
+	0xa1, 0x90, 0x2b, 0x72, 0x08, 0x30, 0x45, 0x73, 0x42, 0x5d, 0x9b, 0x4f, 0xb9, 0x56, 0x0b, 0x6f,
+	0x1a, 0x4b, 0x88, 0x0e, 0xeb, 0x26, 0xf5, 0xa8, 0x69, 0x07, 0x33, 0xad, 0x7e, 0x5c, 0xea, 0x6c,
+	0x0e, 0xa3, 0x35, 0x79, 0x0a, 0x6d, 0x1f, 0xa7, 0x2c, 0xc0, 0x51, 0xc2, 0xc5, 0xba, 0x70, 0xd1,
+	0x0a, 0x15, 0xdf, 0x44, 0xf2, 0xfb, 0xa4, 0xea, 0x05, 0xec, 0xaa, 0xe0, 0xc5, 0x89, 0x42, 0xd7,
+	0xf2, 0x98, 0xed, 0x06, 0x23, 0xdb, 0x92, 0xde, 0x60, 0x21, 0x3a, 0xb7, 0x8c, 0x5b, 0xd8, 0x3e,
+	0x45, 0xc7, 0xe6, 0x81, 0x92, 0xa6, 0x55, 0x86, 0xcb, 0x78, 0x97, 0x57, 0xe0, 0x5d, 0x49, 0xe1,
+	0x6d, 0xec, 0xc1, 0x8e, 0x72, 0x70, 0x18, 0xb2, 0xc1, 0xa1, 0x75, 0x86, 0xd4, 0x0f, 0xc6, 0x48,
+	0x83, 0xff, 0x2d, 0x9a, 0x01, 0xb4, 0x13, 0x87, 0x4a, 0xf0, 0x3e, 0x82, 0xed, 0x4b, 0x9f, 0x9a,
+	0x38, 0xf2, 0xd0, 0xb7, 0x99, 0x35, 0xe2, 0x68, 0x32, 0x57, 0x94, 0x7b, 0xa9, 0x53, 0x19, 0x12,
+	0xa1, 0x7b, 0x23, 0x54, 0x6f, 0x43, 0x8d, 0xd1, 0x83, 0xf6, 0xd7, 0xa8, 0x42, 0xf9, 0x18, 0x20,
+	0x6e, 0x16, 0x19, 0x7b, 0x23, 0xea, 0x15, 0xe3, 0x97, 0x32, 0x90, 0xa4, 0x91, 0x3c, 0xbc, 0xd8,
+	0x2a, 0xb3, 0x05, 0x1e, 0x41, 0x43, 0x2d, 0xff, 0x58, 0x40, 0xbe, 0x8c, 0x1a, 0xaf, 0x2a, 0x1a,
+	0xef, 0xbd, 0xa8, 0xf1, 0x96, 0x4f, 0xcf, 0xec, 0xba, 0x13, 0xd8, 0x48, 0x24, 0x81, 0x6b, 0x6b,
+	0xa2, 0x90, 0x9b, 0x71, 0x16, 0xee, 0x55, 0xc3, 0xcf, 0x05, 0x0a, 0x03, 0xe9, 0xec, 0xae, 0x89,
+	0x37, 0xfe, 0xaa, 0xc2, 0xbb, 0x29, 0xbb, 0x3b, 0x16, 0xfe, 0x7f, 0x00, 0xf0, 0x2b, 0x05, 0xc0,
+	0x4e, 0x12, 0x40, 0x35, 0x80, 0x5c, 0xde, 0x62, 0x16, 0x4a, 0x86, 0x11, 0xdf, 0xa9, 0xa2, 0xac,
+	0xe5, 0x51, 0x52, 0x3d, 0x45, 0x49, 0x23, 0x68, 0x53, 0xeb, 0x06, 0xfd, 0xc0, 0xe6, 0x68, 0x8d,
+	0x64, 0x4c, 0xeb, 0x22, 0xa6, 0x5e, 0x61, 0x4c, 0x2f, 0x23, 0xab, 0x64, 0x74, 0x2d, 0xaa, 0x88,
+	0x15, 0xce, 0x6b, 0x14, 0x72, 0x1e, 0xdc, 0x85, 0xf3, 0x9a, 0x0f, 0xce, 0x79, 0x7a, 0x1f, 0x76,
+	0x32, 0xaf, 0xf3, 0xaf, 0x8a, 0xee, 0xb7, 0x12, 0x6c, 0x0f, 0x7e, 0xf2, 0x18, 0xc7, 0xfe, 0x84,
+	0xba, 0x2e, 0x3a, 0x8b, 0xba, 0x7b, 0xa9, 0xbc, 0x52, 0xef, 0x47, 0xb8, 0x66, 0x6d, 0x7f, 0xe8,
+	0xa7, 0xf7, 0x13, 0xd8, 0x51, 0x8e, 0x89, 0x49, 0xc1, 0x0c, 0x45, 0x09, 0x52, 0x90, 0x92, 0x73,
+	0x6b, 0x6e, 0xd7, 0x67, 0xae, 0x89, 0xd4, 0x51, 0xae, 0xb3, 0xc2, 0x4e, 0x83, 0x5d, 0xd5, 0x4e,
+	0x92, 0xf1, 0x1f, 0x15, 0xd8, 0xeb, 0xfb, 0x48, 0x03, 0xec, 0x33, 0xd7, 0x45, 0x33, 0xb0, 0x99,
+	0xbb, 0x70, 0x7a, 0xaa, 0x60, 0xf4, 0x61, 0x84, 0x51, 0x8e, 0x45, 0x66, 0x4f, 0xdc, 0x93, 0xb9,
+	0x15, 0x9e, 0xac, 0xaa, 0x3c, 0xa9, 0x41, 0x5d, 0xde, 0x53, 0x76, 0xdd, 0x62, 0x39, 0xd7, 0x78,
+	0x74, 0xe6, 0x30, 0x6a, 0x45, 0x7d, 0x17, 0x2e, 0xc9, 0xa7, 0xa9, 0xf2, 0xaf, 0x8b, 0xcb, 0xed,
+	0x75, 0x17, 0x83, 0xdb, 0x6b, 0x66, 0x52, 0x27, 0xaa, 0xe1, 0x54, 0x5f, 0x0c, 0xf2, 0xde, 0xfb,
+	0x66, 0x4f, 0x8b, 0xec, 0x87, 0xe9, 0x26, 0x78, 0xd8, 0x49, 0xe0, 0xd7, 0x32, 0x68, 0xcb, 0xe8,
+	0xcb, 0xea, 0x79, 0x02, 0x9b, 0x66, 0x24, 0x8d, 0x0b, 0x61, 0x23, 0x16, 0x9e, 0x5b, 0x2a, 0x71,
+	0x96, 0x97, 0x88, 0x33, 0x81, 0x68, 0x25, 0x8d, 0xe8, 0x73, 0x68, 0x44, 0xf7, 0x16, 0x99, 0x28,
+	0x80, 0x2d, 0xde, 0x99, 0x3b, 0x79, 0xf5, 0xa1, 0xa5, 0xa2, 0x29, 0x32, 0x55, 0x04, 0xe6, 0x96,
+	0x02, 0xa6, 0xf1, 0x33, 0x68, 0xa7, 0xc8, 0x03, 0x9f, 0xcd, 0x96, 0x0b, 0xf8, 0x4e, 0x78, 0xdc,
+	0x77, 0xb2, 0x38, 0x80, 0xfd, 0x8c, 0x00, 0xc2, 0x8c, 0xf4, 0xfe, 0xac, 0xc1, 0xd6, 0x45, 0xf8,
+	0xcf, 0x41, 0xbe, 0xc0, 0x9c, 0x7c, 0x07, 0x5b, 0xca, 0x80, 0x4f, 0x8e, 0x56, 0x8c, 0xfe, 0xfa,
+	0x49, 0xb4, 0x21, 0x77, 0x64, 0xff, 0x16, 0xde, 0x49, 0xcf, 0x88, 0xe4, 0xb0, 0x78, 0xf2, 0xd6,
+	0x8f, 0x72, 0xf5, 0xd2, 0xe5, 0x05, 0x6c, 0xa6, 0x46, 0x38, 0xf2, 0x38, 0x8e, 0x33, 0x63, 0xa6,
+	0xd4, 0x0f, 0xf3, 0xd4, 0xd2, 0xdf, 0x2b, 0x68, 0x44, 0x43, 0x18, 0xd9, 0x8f, 0x36, 0xab, 0xd3,
+	0xa0, 0xae, 0x67, 0xa9, 0xa4, 0x8f, 0x01, 0x40, 0x3c, 0xce, 0x10, 0x3d, 0x73, 0xc6, 0x09, 0xbd,
+	0x1c, 0x14, 0xcc, 0x3f, 0xe4, 0x0c, 0x9a, 0x89, 0x07, 0x94, 0x1c, 0x64, 0x3f, 0xab, 0xa1, 0xa3,
+	0x47, 0x45, 0x6f, 0xee, 0x1c, 0xa4, 0x14, 0x97, 0x27, 0x40, 0xca, 0x7a, 0x4a, 0xf4, 0xc3, 0x3c,
+	0x75, 0x9c, 0xc7, 0x34, 0x57, 0x27, 0xf2, 0x98, 0x49, 0xfe, 0xfa, 0x51, 0xae, 0x5e, 0xba, 0xfc,
+	0x1e, 0x5a, 0x2a, 0x67, 0x90, 0xe3, 0x55, 0x64, 0xae, 0x9f, 0x14, 0xec, 0x90, 0x8e, 0x7f, 0x80,
+	0xf6, 0x52, 0xed, 0x93, 0x93, 0x44, 0x15, 0x64, 0x37, 0xa6, 0x6e, 0x14, 0x6d, 0x09, 0x7d, 0x8f,
+	0x6b, 0xe2, 0x0f, 0xf4, 0xc7, 0xff, 0x0c, 0x00, 0x71, 0x08, 0x1b, 0xff, 0xa0, 0x0f, 0x00, 0x00,
 }
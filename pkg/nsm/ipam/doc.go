@@ -0,0 +1,30 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipam allocates the point-to-point addresses of connections from the
+// prefixes of their NetworkService. Each connection gets a subnet of its own,
+// a /30 from IPv4 prefixes and a /127 from IPv6 prefixes, holding the address
+// of the client and the address of the endpoint:
+//
+//   - in a /30, the client gets the first host address and the endpoint the
+//     second one, the network and broadcast addresses are left unused
+//   - in a /127, the client gets the first address and the endpoint the
+//     second one, as recommended by RFC 6164
+//
+// A Pool is kept in memory only. Allocations are persisted by their owner,
+// e.g. in the status of the connection, and restored with Reserve when the
+// pool is rebuilt, so that addresses survive restarts. Subnets are allocated
+// in the order of the prefixes and of the addresses, the lowest free subnet
+// first, so that the same allocations always yield the same addresses.
+package ipam
@@ -0,0 +1,280 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Lengths of the subnets allocated to connections
+const (
+	IPv4SubnetLength = 30
+	IPv6SubnetLength = 127
+)
+
+// ErrExhausted is returned when all the subnets of a pool are allocated.
+var ErrExhausted = errors.New("no free subnet left in the prefixes")
+
+// Allocation is the subnet allocated to a connection, along with the addresses
+// of its ends.
+type Allocation struct {
+	Subnet   *net.IPNet
+	Client   net.IP
+	Endpoint net.IP
+}
+
+// Pool allocates subnets from a set of prefixes. A Pool is not safe for
+// concurrent use.
+type Pool struct {
+	prefixes []*prefixPool
+	// owners maps the owners to their allocation
+	owners map[string]*Allocation
+	// subnets maps the allocated subnets, in their CIDR form, to their owner
+	subnets map[string]string
+}
+
+// prefixPool tracks the free subnets of a prefix. The subnets below next are
+// either allocated or listed in free, so that allocating a subnet takes no
+// more steps than the subnets reserved above next.
+type prefixPool struct {
+	prefix *net.IPNet
+	// mask of the subnets, shift is the number of their host bits
+	mask  net.IPMask
+	shift uint
+	// next is the lowest subnet never allocated, nil once past the prefix
+	next net.IP
+	// free lists the released subnets below next, sorted
+	free []net.IP
+}
+
+// NewPool creates a pool allocating subnets from the given prefixes, see
+// ParsePrefixes.
+func NewPool(prefixes []string) (*Pool, error) {
+	parsed, err := ParsePrefixes(prefixes)
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]*prefixPool, 0, len(parsed))
+	for _, prefix := range parsed {
+		ones, bits := subnetLength(prefix), len(prefix.IP)*8
+		pools = append(pools, &prefixPool{
+			prefix: prefix,
+			mask:   net.CIDRMask(ones, bits),
+			shift:  uint(bits - ones),
+			next:   prefix.IP,
+		})
+	}
+	return &Pool{
+		prefixes: pools,
+		owners:   make(map[string]*Allocation),
+		subnets:  make(map[string]string),
+	}, nil
+}
+
+// ParsePrefixes parses prefixes in their CIDR form, e.g. "10.60.0.0/16" or
+// "fd00:60::/64". A prefix must be written with its host bits cleared, be
+// large enough to hold a subnet, and must not overlap the other prefixes.
+func ParsePrefixes(prefixes []string) ([]*net.IPNet, error) {
+	parsed := make([]*net.IPNet, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		ip, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix %q", prefix)
+		}
+		if !ip.Equal(ipNet.IP) {
+			return nil, fmt.Errorf("prefix %s has host bits set, expected %s", prefix, ipNet)
+		}
+		ones, _ := ipNet.Mask.Size()
+		if max := subnetLength(ipNet); ones > max {
+			return nil, fmt.Errorf("prefix %s is too small, expected at most a /%d", prefix, max)
+		}
+		for _, other := range parsed {
+			if Overlap(ipNet, other) {
+				return nil, fmt.Errorf("prefix %s overlaps prefix %s", ipNet, other)
+			}
+		}
+		parsed = append(parsed, ipNet)
+	}
+	return parsed, nil
+}
+
+// Overlap returns true if the prefixes a and b have addresses in common.
+func Overlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// Allocate returns the subnet allocated to owner, allocating the lowest free
+// subnet of the prefixes if it has none. ErrExhausted is returned when no
+// subnet is left.
+func (p *Pool) Allocate(owner string) (*Allocation, error) {
+	if allocation, ok := p.owners[owner]; ok {
+		return allocation, nil
+	}
+	for _, prefix := range p.prefixes {
+		if len(prefix.free) > 0 {
+			subnet := &net.IPNet{IP: prefix.free[0], Mask: prefix.mask}
+			prefix.free = prefix.free[1:]
+			return p.assign(owner, subnet), nil
+		}
+		// Subnets reserved above next are skipped
+		for prefix.next != nil {
+			subnet := &net.IPNet{IP: prefix.next, Mask: prefix.mask}
+			prefix.advance()
+			if _, taken := p.subnets[subnet.String()]; !taken {
+				return p.assign(owner, subnet), nil
+			}
+		}
+	}
+	return nil, ErrExhausted
+}
+
+// Reserve restores the allocation of subnet, in its CIDR form, to owner, e.g.
+// after a restart. The subnet must be a subnet of the prefixes which is free
+// or already allocated to owner, and owner must have no other subnet.
+func (p *Pool) Reserve(owner, subnet string) (*Allocation, error) {
+	ip, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil || !ip.Equal(ipNet.IP) {
+		return nil, fmt.Errorf("invalid subnet %q", subnet)
+	}
+	prefix := p.prefixOf(ipNet.IP)
+	if prefix == nil {
+		return nil, fmt.Errorf("subnet %s is not in the prefixes %s", subnet, p)
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != subnetLength(prefix.prefix) {
+		return nil, fmt.Errorf("subnet %s is not a /%d", subnet, subnetLength(prefix.prefix))
+	}
+	if taken, ok := p.subnets[ipNet.String()]; ok && taken != owner {
+		return nil, fmt.Errorf("subnet %s is already allocated", subnet)
+	}
+	if allocation, ok := p.owners[owner]; ok {
+		if allocation.Subnet.String() != ipNet.String() {
+			return nil, fmt.Errorf("subnet %s is already allocated", allocation.Subnet)
+		}
+		return allocation, nil
+	}
+	if i, ok := prefix.freeIndex(ipNet.IP); ok {
+		prefix.free = append(prefix.free[:i], prefix.free[i+1:]...)
+	}
+	return p.assign(owner, ipNet), nil
+}
+
+// Release frees the subnet allocated to owner, if any.
+func (p *Pool) Release(owner string) {
+	allocation, ok := p.owners[owner]
+	if !ok {
+		return
+	}
+	delete(p.subnets, allocation.Subnet.String())
+	delete(p.owners, owner)
+	// Subnets reserved above next are found by Allocate as it advances
+	prefix := p.prefixOf(allocation.Subnet.IP)
+	if prefix.next == nil || bytes.Compare(allocation.Subnet.IP, prefix.next) < 0 {
+		i, _ := prefix.freeIndex(allocation.Subnet.IP)
+		prefix.free = append(prefix.free, nil)
+		copy(prefix.free[i+1:], prefix.free[i:])
+		prefix.free[i] = allocation.Subnet.IP
+	}
+}
+
+// Allocations returns the allocations of the pool, by owner.
+func (p *Pool) Allocations() map[string]*Allocation {
+	allocations := make(map[string]*Allocation, len(p.owners))
+	for owner, allocation := range p.owners {
+		allocations[owner] = allocation
+	}
+	return allocations
+}
+
+// String returns the prefixes of the pool, sorted.
+func (p *Pool) String() string {
+	prefixes := make([]string, 0, len(p.prefixes))
+	for _, prefix := range p.prefixes {
+		prefixes = append(prefixes, prefix.prefix.String())
+	}
+	sort.Strings(prefixes)
+	return strings.Join(prefixes, ", ")
+}
+
+// assign allocates subnet to owner.
+func (p *Pool) assign(owner string, subnet *net.IPNet) *Allocation {
+	allocation := &Allocation{Subnet: subnet}
+	if len(subnet.IP) == net.IPv4len {
+		allocation.Client = next(subnet.IP, 0)
+		allocation.Endpoint = next(allocation.Client, 0)
+	} else {
+		allocation.Client = subnet.IP
+		allocation.Endpoint = next(subnet.IP, 0)
+	}
+	p.owners[owner] = allocation
+	p.subnets[subnet.String()] = owner
+	return allocation
+}
+
+// prefixOf returns the prefix holding ip, or nil if none does.
+func (p *Pool) prefixOf(ip net.IP) *prefixPool {
+	for _, prefix := range p.prefixes {
+		if prefix.prefix.Contains(ip) && len(prefix.prefix.IP) == len(ip) {
+			return prefix
+		}
+	}
+	return nil
+}
+
+// advance moves next to the following subnet of the prefix.
+func (prefix *prefixPool) advance() {
+	prefix.next = next(prefix.next, prefix.shift)
+	if prefix.next != nil && !prefix.prefix.Contains(prefix.next) {
+		prefix.next = nil
+	}
+}
+
+// freeIndex returns the index of ip in the free subnets, or the index it would
+// be inserted at along with false if it is not free.
+func (prefix *prefixPool) freeIndex(ip net.IP) (int, bool) {
+	i := sort.Search(len(prefix.free), func(i int) bool {
+		return bytes.Compare(prefix.free[i], ip) >= 0
+	})
+	return i, i < len(prefix.free) && prefix.free[i].Equal(ip)
+}
+
+// subnetLength returns the length of the subnets allocated from prefix.
+func subnetLength(prefix *net.IPNet) int {
+	if len(prefix.IP) == net.IPv4len {
+		return IPv4SubnetLength
+	}
+	return IPv6SubnetLength
+}
+
+// next returns ip incremented by 2^shift, or nil if it overflows.
+func next(ip net.IP, shift uint) net.IP {
+	result := make(net.IP, len(ip))
+	copy(result, ip)
+	i := len(result) - 1 - int(shift/8)
+	carry := uint(1) << (shift % 8)
+	for ; i >= 0 && carry > 0; i-- {
+		sum := uint(result[i]) + carry
+		result[i] = byte(sum)
+		carry = sum >> 8
+	}
+	if carry > 0 {
+		return nil
+	}
+	return result
+}
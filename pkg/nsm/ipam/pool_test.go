@@ -0,0 +1,271 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T, prefixes ...string) *Pool {
+	pool, err := NewPool(prefixes)
+	if err != nil {
+		t.Fatalf("NewPool(%v) failed: %s", prefixes, err)
+	}
+	return pool
+}
+
+// checkAllocation checks the subnet and the addresses of an allocation.
+func checkAllocation(t *testing.T, allocation *Allocation, subnet, client, endpoint string) {
+	if allocation.Subnet.String() != subnet || allocation.Client.String() != client || allocation.Endpoint.String() != endpoint {
+		t.Fatalf("expected subnet %s with client %s and endpoint %s, got %s with %s and %s",
+			subnet, client, endpoint, allocation.Subnet, allocation.Client, allocation.Endpoint)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		// expected subnet, client and endpoint of the successive allocations
+		expected [][3]string
+	}{
+		{
+			name:     "IPv4 /30",
+			prefixes: []string{"10.60.0.0/29"},
+			expected: [][3]string{
+				{"10.60.0.0/30", "10.60.0.1", "10.60.0.2"},
+				{"10.60.0.4/30", "10.60.0.5", "10.60.0.6"},
+			},
+		},
+		{
+			name:     "IPv6 /127",
+			prefixes: []string{"fd00:60::/126"},
+			expected: [][3]string{
+				{"fd00:60::/127", "fd00:60::", "fd00:60::1"},
+				{"fd00:60::2/127", "fd00:60::2", "fd00:60::3"},
+			},
+		},
+		{
+			name:     "prefixes in order",
+			prefixes: []string{"10.60.0.0/30", "fd00:60::/127", "10.61.0.0/30"},
+			expected: [][3]string{
+				{"10.60.0.0/30", "10.60.0.1", "10.60.0.2"},
+				{"fd00:60::/127", "fd00:60::", "fd00:60::1"},
+				{"10.61.0.0/30", "10.61.0.1", "10.61.0.2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := newTestPool(t, test.prefixes...)
+			for i, expected := range test.expected {
+				owner := fmt.Sprintf("conn-%d", i)
+				allocation, err := pool.Allocate(owner)
+				if err != nil {
+					t.Fatalf("Allocate(%s) failed: %s", owner, err)
+				}
+				checkAllocation(t, allocation, expected[0], expected[1], expected[2])
+
+				// Owners keep their allocation
+				again, err := pool.Allocate(owner)
+				if err != nil || again != allocation {
+					t.Fatalf("expected Allocate(%s) to return the same allocation, got %v, %v", owner, again, err)
+				}
+			}
+			if _, err := pool.Allocate("extra"); err != ErrExhausted {
+				t.Fatalf("expected ErrExhausted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAllocateExhausted(t *testing.T) {
+	pool := newTestPool(t, "10.60.0.0/30")
+	if _, err := pool.Allocate("conn-0"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := pool.Allocate(fmt.Sprintf("extra-%d", i)); err != ErrExhausted {
+			t.Fatalf("expected ErrExhausted, got %v", err)
+		}
+	}
+	if len(pool.Allocations()) != 1 {
+		t.Errorf("failed allocations were recorded: %v", pool.Allocations())
+	}
+}
+
+func TestAllocateFullIPv6Prefix(t *testing.T) {
+	// Filling a /64 is out of reach, so the pool is filled by reservations
+	// above the lowest subnet: allocating must not walk the whole prefix
+	pool := newTestPool(t, "fd00:60::/64")
+	if _, err := pool.Reserve("reserved", "fd00:60::2/127"); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			if _, err := pool.Allocate(fmt.Sprintf("conn-%d", i)); err != nil {
+				t.Errorf("Allocate failed: %s", err)
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("allocating from a large prefix takes too long")
+	}
+	allocations := pool.Allocations()
+	checkAllocation(t, allocations["conn-0"], "fd00:60::/127", "fd00:60::", "fd00:60::1")
+	checkAllocation(t, allocations["conn-1"], "fd00:60::4/127", "fd00:60::4", "fd00:60::5")
+}
+
+func TestReleaseAndReuse(t *testing.T) {
+	pool := newTestPool(t, "10.60.0.0/28")
+	for i := 0; i < 4; i++ {
+		if _, err := pool.Allocate(fmt.Sprintf("conn-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.Release("conn-2")
+	pool.Release("conn-1")
+	pool.Release("unknown")
+	if _, err := pool.Allocate("extra"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lowest free subnet is allocated first
+	allocation, err := pool.Allocate("conn-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAllocation(t, allocation, "10.60.0.8/30", "10.60.0.9", "10.60.0.10")
+	checkAllocation(t, pool.Allocations()["extra"], "10.60.0.4/30", "10.60.0.5", "10.60.0.6")
+	if _, err := pool.Allocate("conn-5"); err != ErrExhausted {
+		t.Fatalf("expected ErrExhausted, got %v", err)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	// The allocations survive a restart by reserving them in a new pool
+	pool := newTestPool(t, "10.60.0.0/28")
+	for i := 0; i < 3; i++ {
+		if _, err := pool.Allocate(fmt.Sprintf("conn-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.Release("conn-0")
+
+	restarted := newTestPool(t, "10.60.0.0/28")
+	for owner, allocation := range pool.Allocations() {
+		reserved, err := restarted.Reserve(owner, allocation.Subnet.String())
+		if err != nil {
+			t.Fatalf("Reserve(%s, %s) failed: %s", owner, allocation.Subnet, err)
+		}
+		checkAllocation(t, reserved, allocation.Subnet.String(), allocation.Client.String(), allocation.Endpoint.String())
+	}
+
+	// New allocations skip the reserved subnets
+	allocation, err := restarted.Allocate("conn-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAllocation(t, allocation, "10.60.0.0/30", "10.60.0.1", "10.60.0.2")
+	allocation, err = restarted.Allocate("conn-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAllocation(t, allocation, "10.60.0.12/30", "10.60.0.13", "10.60.0.14")
+
+	// A subnet released below the reservations is allocated again
+	restarted.Release("conn-3")
+	if _, err := restarted.Reserve("conn-5", "10.60.0.0/30"); err != nil {
+		t.Fatalf("Reserve of a released subnet failed: %s", err)
+	}
+	if _, err := restarted.Allocate("conn-6"); err != ErrExhausted {
+		t.Fatalf("expected ErrExhausted once the released subnet is reserved, got %v", err)
+	}
+}
+
+func TestReserveConflicts(t *testing.T) {
+	pool := newTestPool(t, "10.60.0.0/28", "fd00:60::/126")
+	if _, err := pool.Reserve("conn-0", "10.60.0.4/30"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		owner  string
+		subnet string
+	}{
+		{"invalid subnet", "conn-1", "10.60.0.4"},
+		{"host bits set", "conn-1", "10.60.0.5/30"},
+		{"outside the prefixes", "conn-1", "10.61.0.0/30"},
+		{"wrong length", "conn-1", "10.60.0.0/29"},
+		{"wrong IPv6 length", "conn-1", "fd00:60::/126"},
+		{"subnet of another owner", "conn-1", "10.60.0.4/30"},
+		{"owner with another subnet", "conn-0", "10.60.0.8/30"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := pool.Reserve(test.owner, test.subnet); err == nil {
+				t.Fatalf("Reserve(%s, %s) succeeded", test.owner, test.subnet)
+			}
+		})
+	}
+
+	// Reserving the subnet of the owner again is allowed
+	if _, err := pool.Reserve("conn-0", "10.60.0.4/30"); err != nil {
+		t.Errorf("Reserve of the subnet of the owner failed: %s", err)
+	}
+	if len(pool.Allocations()) != 1 {
+		t.Errorf("failed reservations were recorded: %v", pool.Allocations())
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		valid    bool
+	}{
+		{"IPv4 and IPv6", []string{"10.60.0.0/16", "fd00:60::/64"}, true},
+		{"smallest prefixes", []string{"10.60.0.0/30", "fd00:60::/127"}, true},
+		{"no prefixes", nil, true},
+		{"not a prefix", []string{"10.60.0.0"}, false},
+		{"IPv4 host bits", []string{"10.60.0.1/16"}, false},
+		{"IPv6 host bits", []string{"fd00:60::1/64"}, false},
+		{"IPv4 prefix too small", []string{"10.60.0.0/31"}, false},
+		{"IPv6 prefix too small", []string{"fd00:60::/128"}, false},
+		{"overlapping prefixes", []string{"10.60.0.0/16", "10.60.4.0/24"}, false},
+		{"overlapping larger prefix", []string{"10.60.4.0/24", "10.60.0.0/16"}, false},
+		{"duplicate prefixes", []string{"fd00:60::/64", "fd00:60::/64"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePrefixes(test.prefixes)
+			if test.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mechanism negotiates the mechanisms realising a connection between
+// a client and an endpoint. Local mechanisms plug the connection into the
+// pods, e.g. a kernel interface or a memif, and remote mechanisms carry it
+// between their nodes, e.g. a VXLAN tunnel.
+//
+// The client lists the mechanisms it accepts in order of preference, along
+// with their parameters, and the endpoint lists the names of the mechanisms it
+// supports. The negotiation is deterministic, the same lists always yield the
+// same mechanism:
+//
+//   - the first mechanism of the client the endpoint supports is picked, with
+//     the parameters of the client, whatever the order of the endpoint
+//   - an endpoint which lists no mechanism supports all the known ones
+//   - when the client lists no mechanism, the first known mechanism of the
+//     endpoint is picked without parameters, and nothing is picked when the
+//     endpoint lists none either
+//   - otherwise a *NoCommonMechanismError is returned
+package mechanism
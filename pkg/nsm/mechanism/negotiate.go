@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanism
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+// NoCommonMechanismError is returned when the endpoint supports none of the
+// mechanisms of the client.
+type NoCommonMechanismError struct {
+	// Preferred are the types of the mechanisms of the client
+	Preferred []string
+	// Supported are the mechanisms of the endpoint
+	Supported []string
+}
+
+// Error implements the error interface.
+func (e *NoCommonMechanismError) Error() string {
+	if len(e.Preferred) == 0 {
+		return fmt.Sprintf("the endpoint supports none of the known mechanisms, it supports %s",
+			strings.Join(e.Supported, ", "))
+	}
+	supported := "all the known mechanisms"
+	if len(e.Supported) > 0 {
+		supported = strings.Join(e.Supported, ", ")
+	}
+	return fmt.Sprintf("none of the mechanisms %s is supported, the endpoint supports %s",
+		strings.Join(e.Preferred, ", "), supported)
+}
+
+// NegotiateLocal picks the local mechanism plugging a connection into the
+// client, among the mechanisms preferred by the client and the names of the
+// local mechanisms the endpoint supports, see the package documentation. The
+// mechanism returned is a copy, nil when neither lists any mechanism.
+func NegotiateLocal(preferred []*netmesh.LocalMechanism, supported []string) (*netmesh.LocalMechanism, error) {
+	types := make([]string, 0, len(preferred))
+	for _, mechanism := range preferred {
+		if mechanism != nil {
+			types = append(types, mechanism.Type)
+		}
+	}
+	name, err := negotiate(types, supported, func(name string) bool {
+		mechanism, err := netmesh.ParseLocalMechanismType(name)
+		return err == nil && mechanism != netmesh.LocalMechanismType_LOCAL_MECHANISM_UNSPECIFIED
+	})
+	if err != nil || name == "" {
+		return nil, err
+	}
+	for _, mechanism := range preferred {
+		if mechanism != nil && mechanism.Type == name {
+			return mechanism.DeepCopy(), nil
+		}
+	}
+	return &netmesh.LocalMechanism{Type: name}, nil
+}
+
+// NegotiateRemote picks the remote mechanism carrying a connection between
+// the nodes of the client and the endpoint, among the mechanisms preferred by
+// the client and the names of the remote mechanisms the endpoint supports, see
+// the package documentation. The mechanism returned is a copy, nil when
+// neither lists any mechanism.
+func NegotiateRemote(preferred []*netmesh.RemoteMechanism, supported []string) (*netmesh.RemoteMechanism, error) {
+	types := make([]string, 0, len(preferred))
+	for _, mechanism := range preferred {
+		if mechanism != nil {
+			types = append(types, mechanism.Type)
+		}
+	}
+	name, err := negotiate(types, supported, func(name string) bool {
+		mechanism, err := netmesh.ParseRemoteMechanismType(name)
+		return err == nil && mechanism != netmesh.RemoteMechanismType_REMOTE_MECHANISM_UNSPECIFIED
+	})
+	if err != nil || name == "" {
+		return nil, err
+	}
+	for _, mechanism := range preferred {
+		if mechanism != nil && mechanism.Type == name {
+			return mechanism.DeepCopy(), nil
+		}
+	}
+	return &netmesh.RemoteMechanism{Type: name}, nil
+}
+
+// negotiate returns the name of the mechanism picked among the types
+// preferred by the client and the names supported by the endpoint, or an
+// empty name when neither lists any. known returns true for the names of the
+// known mechanisms.
+func negotiate(preferred, supported []string, known func(name string) bool) (string, error) {
+	if len(preferred) == 0 {
+		for _, name := range supported {
+			if known(name) {
+				return name, nil
+			}
+		}
+		if len(supported) == 0 {
+			return "", nil
+		}
+		return "", &NoCommonMechanismError{Supported: supported}
+	}
+
+	for _, name := range preferred {
+		if supports(supported, name, known) {
+			return name, nil
+		}
+	}
+	return "", &NoCommonMechanismError{Preferred: preferred, Supported: supported}
+}
+
+// supports returns true if an endpoint supporting the given mechanisms
+// supports the mechanism with the given name. Endpoints which list no
+// mechanism support all the known ones.
+func supports(supported []string, name string, known func(name string) bool) bool {
+	if len(supported) == 0 {
+		return known(name)
+	}
+	for _, s := range supported {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanism
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+// Local mechanisms with parameters, to check that the parameters of the
+// client are kept
+var (
+	kernel = &netmesh.LocalMechanism{
+		Type:            netmesh.MechanismNameKernelInterface,
+		KernelInterface: &netmesh.KernelInterfaceParameters{Name: "nsm0"},
+	}
+	otherKernel = &netmesh.LocalMechanism{
+		Type:            netmesh.MechanismNameKernelInterface,
+		KernelInterface: &netmesh.KernelInterfaceParameters{Name: "nsm1"},
+	}
+	memif = &netmesh.LocalMechanism{
+		Type:  netmesh.MechanismNameMemif,
+		Memif: &netmesh.MemifParameters{Socket: "/run/nsm/memif.sock", Id: 1},
+	}
+	vhostUser = &netmesh.LocalMechanism{Type: netmesh.MechanismNameVhostUser}
+)
+
+// Remote mechanisms with parameters
+var (
+	vxlan = &netmesh.RemoteMechanism{
+		Type:  netmesh.MechanismNameVXLAN,
+		Vxlan: &netmesh.VxlanParameters{Vni: 42},
+	}
+	otherVxlan = &netmesh.RemoteMechanism{
+		Type:  netmesh.MechanismNameVXLAN,
+		Vxlan: &netmesh.VxlanParameters{Vni: 43},
+	}
+	gre = &netmesh.RemoteMechanism{
+		Type: netmesh.MechanismNameGRE,
+		Gre:  &netmesh.GreParameters{Key: 7},
+	}
+	srv6 = &netmesh.RemoteMechanism{Type: netmesh.MechanismNameSRv6}
+)
+
+func TestNegotiateLocal(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []*netmesh.LocalMechanism
+		supported []string
+		expected  *netmesh.LocalMechanism
+		// noCommon is set when a *NoCommonMechanismError is expected
+		noCommon bool
+	}{
+		{
+			name:      "client preference wins over the order of the endpoint",
+			preferred: []*netmesh.LocalMechanism{memif, kernel},
+			supported: []string{netmesh.MechanismNameKernelInterface, netmesh.MechanismNameMemif},
+			expected:  memif,
+		},
+		{
+			name:      "first supported preference",
+			preferred: []*netmesh.LocalMechanism{vhostUser, kernel, memif},
+			supported: []string{netmesh.MechanismNameMemif, netmesh.MechanismNameKernelInterface},
+			expected:  kernel,
+		},
+		{
+			name:      "endpoint without mechanisms supports all the known ones",
+			preferred: []*netmesh.LocalMechanism{memif, kernel},
+			expected:  memif,
+		},
+		{
+			name:      "client without mechanisms gets the first known mechanism of the endpoint",
+			supported: []string{"carrier-pigeon", netmesh.MechanismNameVhostUser, netmesh.MechanismNameMemif},
+			expected:  &netmesh.LocalMechanism{Type: netmesh.MechanismNameVhostUser},
+		},
+		{
+			name:      "client without mechanisms and endpoint without known mechanisms",
+			supported: []string{"carrier-pigeon"},
+			noCommon:  true,
+		},
+		{
+			name: "neither lists any mechanism",
+		},
+		{
+			name:      "nil mechanisms of the client are ignored",
+			preferred: []*netmesh.LocalMechanism{nil},
+			expected:  nil,
+		},
+		{
+			name:      "no common mechanism",
+			preferred: []*netmesh.LocalMechanism{kernel, vhostUser},
+			supported: []string{netmesh.MechanismNameMemif},
+			noCommon:  true,
+		},
+		{
+			name:      "unknown mechanism of the client is not supported by default",
+			preferred: []*netmesh.LocalMechanism{{Type: "carrier-pigeon"}},
+			noCommon:  true,
+		},
+		{
+			name:      "duplicate mechanisms of the client keep the first parameters",
+			preferred: []*netmesh.LocalMechanism{nil, kernel, otherKernel, memif},
+			supported: []string{netmesh.MechanismNameMemif, netmesh.MechanismNameKernelInterface},
+			expected:  kernel,
+		},
+		{
+			name:      "duplicate mechanisms of the endpoint",
+			preferred: []*netmesh.LocalMechanism{vhostUser, memif},
+			supported: []string{netmesh.MechanismNameMemif, netmesh.MechanismNameMemif},
+			expected:  memif,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The same lists always yield the same mechanism
+			for i := 0; i < 3; i++ {
+				negotiated, err := NegotiateLocal(test.preferred, test.supported)
+				checkNoCommon(t, err, test.noCommon)
+				if !reflect.DeepEqual(negotiated, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, negotiated)
+				}
+				// The mechanism returned is a copy
+				for _, m := range test.preferred {
+					if negotiated != nil && m == negotiated {
+						t.Fatal("negotiated mechanism is a mechanism of the client, not a copy")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNegotiateRemote(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []*netmesh.RemoteMechanism
+		supported []string
+		expected  *netmesh.RemoteMechanism
+		noCommon  bool
+	}{
+		{
+			name:      "client preference wins over the order of the endpoint",
+			preferred: []*netmesh.RemoteMechanism{gre, vxlan},
+			supported: []string{netmesh.MechanismNameVXLAN, netmesh.MechanismNameGRE},
+			expected:  gre,
+		},
+		{
+			name:      "endpoint without mechanisms supports all the known ones",
+			preferred: []*netmesh.RemoteMechanism{srv6, vxlan},
+			expected:  srv6,
+		},
+		{
+			name:      "client without mechanisms gets the first known mechanism of the endpoint",
+			supported: []string{"carrier-pigeon", netmesh.MechanismNameMPLSoGRE},
+			expected:  &netmesh.RemoteMechanism{Type: netmesh.MechanismNameMPLSoGRE},
+		},
+		{
+			name: "neither lists any mechanism",
+		},
+		{
+			name:      "local mechanisms are not remote mechanisms",
+			supported: []string{netmesh.MechanismNameKernelInterface},
+			noCommon:  true,
+		},
+		{
+			name:      "no common mechanism",
+			preferred: []*netmesh.RemoteMechanism{vxlan},
+			supported: []string{netmesh.MechanismNameGRE, netmesh.MechanismNameSRv6},
+			noCommon:  true,
+		},
+		{
+			name:      "duplicate mechanisms of the client keep the first parameters",
+			preferred: []*netmesh.RemoteMechanism{vxlan, otherVxlan, gre},
+			supported: []string{netmesh.MechanismNameGRE, netmesh.MechanismNameVXLAN, netmesh.MechanismNameVXLAN},
+			expected:  vxlan,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 3; i++ {
+				negotiated, err := NegotiateRemote(test.preferred, test.supported)
+				checkNoCommon(t, err, test.noCommon)
+				if !reflect.DeepEqual(negotiated, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, negotiated)
+				}
+				for _, m := range test.preferred {
+					if negotiated != nil && m == negotiated {
+						t.Fatal("negotiated mechanism is a mechanism of the client, not a copy")
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkNoCommon checks that err is a *NoCommonMechanismError if noCommon is
+// set, and nil otherwise.
+func checkNoCommon(t *testing.T, err error, noCommon bool) {
+	if !noCommon {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return
+	}
+	if _, ok := err.(*NoCommonMechanismError); !ok {
+		t.Fatalf("expected a *NoCommonMechanismError, got %v", err)
+	}
+}
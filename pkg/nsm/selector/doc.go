@@ -0,0 +1,26 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selector evaluates the endpoint selectors of NetworkServices against
+// the labels of NetworkServiceEndpoints. A NetworkService selects endpoints
+// with a selector string in the Kubernetes label selector syntax and with a
+// structured label selector made of matchLabels and matchExpressions, an
+// endpoint has to match both. As in Kubernetes, a bare key in the selector
+// string, e.g. "routing", selects the endpoints carrying that label whatever
+// its value.
+//
+// Endpoints are only selected in the namespace of the NetworkService, unless
+// the service imports services of other namespaces which export themselves to
+// its namespace, see ExportedTo.
+package selector
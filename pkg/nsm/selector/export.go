@@ -0,0 +1,53 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+// ExportedTo returns true if a NetworkService living in namespace home can be
+// imported from namespace, whose labels are namespaceLabels. A service is
+// always visible from its own namespace, other namespaces have to be listed
+// by name in the export policy or match its namespace selector, if any.
+func ExportedTo(ns *netmesh.NetworkService, home, namespace string, namespaceLabels map[string]string) (bool, error) {
+	if namespace == home {
+		return true, nil
+	}
+	policy := ns.ExportTo
+	if policy == nil {
+		return false, nil
+	}
+	for _, exported := range policy.Namespaces {
+		if exported == namespace {
+			return true, nil
+		}
+	}
+	if policy.NamespaceSelector == nil {
+		return false, nil
+	}
+
+	requirements, err := Requirements(policy.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %s", err)
+	}
+	// As in Kubernetes network policies, an empty namespace selector exports
+	// the service to all namespaces
+	return labels.NewSelector().Add(requirements...).Matches(labels.Set(namespaceLabels)), nil
+}
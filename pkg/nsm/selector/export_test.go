@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+func TestExportedTo(t *testing.T) {
+	prod := map[string]string{"env": "prod"}
+	tests := []struct {
+		name      string
+		policy    *netmesh.ExportPolicy
+		namespace string
+		labels    map[string]string
+		exported  bool
+		invalid   bool
+	}{
+		{"own namespace without policy", nil, "home", nil, true, false},
+		{"other namespace without policy", nil, "team-a", prod, false, false},
+		{"empty policy", &netmesh.ExportPolicy{}, "team-a", prod, false, false},
+		{
+			name:      "namespace listed",
+			policy:    &netmesh.ExportPolicy{Namespaces: []string{"team-b", "team-a"}},
+			namespace: "team-a",
+			exported:  true,
+		},
+		{
+			name:      "namespace not listed",
+			policy:    &netmesh.ExportPolicy{Namespaces: []string{"team-b"}},
+			namespace: "team-a",
+			labels:    prod,
+		},
+		{
+			name:      "namespace selector matching",
+			policy:    &netmesh.ExportPolicy{NamespaceSelector: &netmesh.LabelSelector{MatchLabels: prod}},
+			namespace: "team-a",
+			labels:    prod,
+			exported:  true,
+		},
+		{
+			name:      "namespace selector not matching",
+			policy:    &netmesh.ExportPolicy{NamespaceSelector: &netmesh.LabelSelector{MatchLabels: prod}},
+			namespace: "team-a",
+			labels:    map[string]string{"env": "dev"},
+		},
+		{
+			name: "namespace listed or matching the selector",
+			policy: &netmesh.ExportPolicy{
+				Namespaces:        []string{"team-b"},
+				NamespaceSelector: &netmesh.LabelSelector{MatchLabels: prod},
+			},
+			namespace: "team-b",
+			exported:  true,
+		},
+		{
+			name:      "empty namespace selector exports to all namespaces",
+			policy:    &netmesh.ExportPolicy{NamespaceSelector: &netmesh.LabelSelector{}},
+			namespace: "team-a",
+			exported:  true,
+		},
+		{
+			name: "invalid namespace selector",
+			policy: &netmesh.ExportPolicy{NamespaceSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{{Key: "env", Operator: "Like"}},
+			}},
+			namespace: "team-a",
+			invalid:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ns := &netmesh.NetworkService{ExportTo: test.policy}
+			exported, err := ExportedTo(ns, "home", test.namespace, test.labels)
+			if test.invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if exported != test.exported {
+				t.Errorf("expected exported to be %t, got %t", test.exported, exported)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+// Operators of a netmesh.LabelSelectorRequirement
+const (
+	OpIn           string = "In"
+	OpNotIn        string = "NotIn"
+	OpExists       string = "Exists"
+	OpDoesNotExist string = "DoesNotExist"
+)
+
+// Operators lists all the valid operators
+var Operators = []string{OpIn, OpNotIn, OpExists, OpDoesNotExist}
+
+// operators maps the operators to their Kubernetes counterparts
+var operators = map[string]selection.Operator{
+	OpIn:           selection.In,
+	OpNotIn:        selection.NotIn,
+	OpExists:       selection.Exists,
+	OpDoesNotExist: selection.DoesNotExist,
+}
+
+// ForService returns the selector of the endpoints of a NetworkService. Both
+// the selector string and the label selector of the service have to match.
+// An empty selector matches all the endpoints.
+func ForService(ns *netmesh.NetworkService) (labels.Selector, error) {
+	selector, err := labels.Parse(ns.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %s", ns.Selector, err)
+	}
+	requirements, err := Requirements(ns.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %s", err)
+	}
+	return selector.Add(requirements...), nil
+}
+
+// Requirements converts a label selector to the requirements of a Kubernetes
+// selector. The requirements are sorted by key, a nil selector has no
+// requirement.
+func Requirements(ls *netmesh.LabelSelector) ([]labels.Requirement, error) {
+	if ls == nil {
+		return nil, nil
+	}
+
+	var requirements []labels.Requirement
+	for key, value := range ls.MatchLabels {
+		requirement, err := labels.NewRequirement(key, selection.Equals, []string{value})
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *requirement)
+	}
+	for _, expression := range ls.MatchExpressions {
+		if expression == nil {
+			continue
+		}
+		op, ok := operators[expression.Operator]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a valid label selector operator", expression.Operator)
+		}
+		requirement, err := labels.NewRequirement(expression.Key, op, expression.Values)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *requirement)
+	}
+
+	// Map iteration order is random, keep the result stable
+	sort.Sort(labels.ByKey(requirements))
+	return requirements, nil
+}
+
+// Matches returns true if the selector of a NetworkService matches the labels
+// of an endpoint. Invalid selectors do not match anything.
+func Matches(ns *netmesh.NetworkService, endpointLabels map[string]string) bool {
+	selector, err := ForService(ns)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(endpointLabels))
+}
+
+// RequiredKeys returns the label keys an object must have to match the
+// selector, i.e. the keys of its positive requirements. Any of them is
+// enough to find the candidate selectors of an object in an index. When the
+// selector can match objects without any label, e.g. when it is empty or has
+// only negative requirements, no key is returned and all is true.
+func RequiredKeys(selector labels.Selector) (keys []string, all bool) {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		// Nothing is selected
+		return nil, false
+	}
+	for _, requirement := range requirements {
+		switch requirement.Operator() {
+		case selection.NotEquals, selection.NotIn, selection.DoesNotExist:
+			continue
+		}
+		keys = append(keys, requirement.Key())
+	}
+	return keys, len(keys) == 0
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+)
+
+// Labels of the endpoints the selectors are matched against
+var (
+	goldRouting   = map[string]string{"routing": "true", "tier": "gold"}
+	silverRouting = map[string]string{"routing": "false", "tier": "silver"}
+	goldOnly      = map[string]string{"tier": "gold"}
+	noLabels      = map[string]string{}
+)
+
+func TestForService(t *testing.T) {
+	tests := []struct {
+		name          string
+		selector      string
+		labelSelector *netmesh.LabelSelector
+		// matches lists whether goldRouting, silverRouting, goldOnly and
+		// noLabels are selected
+		matches [4]bool
+		invalid bool
+	}{
+		{
+			name:    "empty selector matches all the endpoints",
+			matches: [4]bool{true, true, true, true},
+		},
+		{
+			name:     "a bare key requires the label whatever its value",
+			selector: "routing",
+			matches:  [4]bool{true, true, false, false},
+		},
+		{
+			name:     "set based selector string",
+			selector: "tier in (gold, bronze), routing",
+			matches:  [4]bool{true, false, false, false},
+		},
+		{
+			name:          "matchLabels",
+			labelSelector: &netmesh.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			matches:       [4]bool{true, false, true, false},
+		},
+		{
+			name:     "selector string and label selector are ANDed",
+			selector: "routing",
+			labelSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{
+					{Key: "tier", Operator: OpNotIn, Values: []string{"gold"}},
+				},
+			},
+			matches: [4]bool{false, true, false, false},
+		},
+		{
+			name:          "empty label selector matches all the endpoints",
+			labelSelector: &netmesh.LabelSelector{},
+			matches:       [4]bool{true, true, true, true},
+		},
+		{
+			name:     "invalid selector string",
+			selector: "tier in (gold",
+			invalid:  true,
+		},
+		{
+			name: "invalid label selector",
+			labelSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{{Key: "tier", Operator: "Like"}},
+			},
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ns := &netmesh.NetworkService{Selector: test.selector, LabelSelector: test.labelSelector}
+			selector, err := ForService(ns)
+			if test.invalid {
+				if err == nil {
+					t.Fatalf("expected an error, got selector %q", selector)
+				}
+				if Matches(ns, noLabels) {
+					t.Error("invalid selector matches")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for i, endpointLabels := range []map[string]string{goldRouting, silverRouting, goldOnly, noLabels} {
+				if matches := selector.Matches(labels.Set(endpointLabels)); matches != test.matches[i] {
+					t.Errorf("expected %q to match %v: %t, got %t", selector, endpointLabels, test.matches[i], matches)
+				}
+				if matches := Matches(ns, endpointLabels); matches != test.matches[i] {
+					t.Errorf("expected Matches of %v to be %t, got %t", endpointLabels, test.matches[i], matches)
+				}
+			}
+		})
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	tests := []struct {
+		name          string
+		labelSelector *netmesh.LabelSelector
+		// expected is the selector built from the requirements in its string
+		// form
+		expected string
+		invalid  bool
+	}{
+		{name: "nil selector"},
+		{
+			name: "matchLabels and all the operators, sorted by key",
+			labelSelector: &netmesh.LabelSelector{
+				MatchLabels: map[string]string{"tier": "gold", "app": "router"},
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{
+					{Key: "env", Operator: OpIn, Values: []string{"prod", "staging"}},
+					{Key: "zone", Operator: OpNotIn, Values: []string{"eu"}},
+					{Key: "routing", Operator: OpExists},
+					{Key: "legacy", Operator: OpDoesNotExist},
+					nil,
+				},
+			},
+			expected: "app=router,env in (prod,staging),!legacy,routing,tier=gold,zone notin (eu)",
+		},
+		{
+			name: "invalid operator",
+			labelSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{{Key: "tier", Operator: "Equals", Values: []string{"gold"}}},
+			},
+			invalid: true,
+		},
+		{
+			name: "In without values",
+			labelSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{{Key: "tier", Operator: OpIn}},
+			},
+			invalid: true,
+		},
+		{
+			name: "Exists with values",
+			labelSelector: &netmesh.LabelSelector{
+				MatchExpressions: []*netmesh.LabelSelectorRequirement{{Key: "tier", Operator: OpExists, Values: []string{"gold"}}},
+			},
+			invalid: true,
+		},
+		{
+			name:          "invalid label value",
+			labelSelector: &netmesh.LabelSelector{MatchLabels: map[string]string{"tier": "gold tier"}},
+			invalid:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requirements, err := Requirements(test.labelSelector)
+			if test.invalid {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", requirements)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if selector := labels.NewSelector().Add(requirements...).String(); selector != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, selector)
+			}
+		})
+	}
+}
+
+func TestRequiredKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector labels.Selector
+		keys     []string
+		all      bool
+	}{
+		{"empty selector", labels.Everything(), nil, true},
+		{"nothing selected", labels.Nothing(), nil, false},
+		{"positive requirements", labels.SelectorFromSet(labels.Set{"tier": "gold", "routing": "true"}), []string{"routing", "tier"}, false},
+		{"only negative requirements", mustParse(t, "tier!=gold,!legacy,zone notin (eu)"), nil, true},
+		{"positive and negative requirements", mustParse(t, "env in (prod),!legacy,routing"), []string{"env", "routing"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, all := RequiredKeys(test.selector)
+			if !reflect.DeepEqual(keys, test.keys) || all != test.all {
+				t.Errorf("expected %v, %t, got %v, %t", test.keys, test.all, keys, all)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, selector string) labels.Selector {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		t.Fatalf("error parsing %q: %s", selector, err)
+	}
+	return parsed
+}
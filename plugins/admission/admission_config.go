@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshpluginadmission
+
+import (
+	"fmt"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defaults of the configuration
+const (
+	DefaultEndpoint        = ":8443"
+	DefaultShutdownTimeout = time.Second * 5
+)
+
+// Config holds the configuration of the admission webhook. It is read from
+// the file given by the netmeshadmission-config flag, the webhook is only
+// served when enabled.
+type Config struct {
+	// Enabled turns the webhook on.
+	Enabled bool `json:"enabled"`
+	// Endpoint is the address the webhook listens on.
+	Endpoint string `json:"endpoint"`
+	// CertFile and KeyFile hold the TLS certificate of the webhook, the API
+	// server only calls webhooks over TLS.
+	CertFile string `json:"cert-file"`
+	KeyFile  string `json:"key-file"`
+	// ShutdownTimeout bounds the time given to the reviews in progress
+	// when the plugin is closed.
+	ShutdownTimeout meta.Duration `json:"shutdown-timeout"`
+}
+
+// FixConfig fills the empty fields of the configuration with their default
+// value.
+func FixConfig(cfg *Config) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultEndpoint
+	}
+	if cfg.ShutdownTimeout.Duration == 0 {
+		cfg.ShutdownTimeout = meta.Duration{Duration: DefaultShutdownTimeout}
+	}
+}
+
+// Validate checks the configuration, once its defaults have been filled.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return fmt.Errorf("cert-file and key-file are required to serve the webhook")
+	}
+	if cfg.ShutdownTimeout.Duration < 0 {
+		return fmt.Errorf("shutdown-timeout must not be negative, got %s", cfg.ShutdownTimeout.Duration)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshpluginadmission
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/ligato/cn-infra/flavors/local"
+	"github.com/ligato/networkservicemesh/pkg/nsm/admission"
+	"github.com/ligato/networkservicemesh/plugins/crd"
+)
+
+// Plugin serves the mutating and validating admission webhook of the NSM
+// custom resources over TLS. The webhook configurations registering it with
+// the API server are installed along with netmesh.
+type Plugin struct {
+	Deps
+
+	config *Config
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+// Deps defines dependencies of the admission plugin.
+type Deps struct {
+	local.PluginInfraDeps
+	// CRD gives access to the cached NSM objects, the channels referenced by
+	// NetworkServices are looked up in its cache.
+	CRD netmeshplugincrd.API
+}
+
+// Init loads the configuration of the plugin.
+func (plugin *Plugin) Init() error {
+	plugin.config = &Config{}
+	if plugin.PluginConfig != nil {
+		if _, err := plugin.PluginConfig.GetValue(plugin.config); err != nil {
+			return fmt.Errorf("error loading admission webhook configuration: %s", err)
+		}
+	}
+	FixConfig(plugin.config)
+	if err := plugin.config.Validate(); err != nil {
+		return fmt.Errorf("invalid admission webhook configuration: %s", err)
+	}
+	return nil
+}
+
+// AfterInit starts serving the webhook, if enabled.
+func (plugin *Plugin) AfterInit() error {
+	if !plugin.config.Enabled {
+		plugin.Log.Info("Admission webhook disabled")
+		return nil
+	}
+
+	// Listen here, so that the plugin fails to start when the endpoint is
+	// not available
+	listener, err := net.Listen("tcp", plugin.config.Endpoint)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %s", plugin.config.Endpoint, err)
+	}
+	reviewer := &admission.Reviewer{Channels: plugin.CRD}
+	plugin.server = &http.Server{Handler: reviewer.Handler()}
+
+	plugin.wg.Add(1)
+	go func() {
+		defer plugin.wg.Done()
+		err := plugin.server.ServeTLS(listener, plugin.config.CertFile, plugin.config.KeyFile)
+		if err != http.ErrServerClosed {
+			plugin.Log.Errorf("Admission webhook stopped: %s", err)
+		}
+	}()
+	plugin.Log.Infof("Serving admission webhook on %s", plugin.config.Endpoint)
+
+	return nil
+}
+
+// Close stops the webhook, letting the reviews in progress finish.
+func (plugin *Plugin) Close() error {
+	if plugin.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), plugin.config.ShutdownTimeout.Duration)
+	defer cancel()
+	err := plugin.server.Shutdown(ctx)
+	plugin.wg.Wait()
+	return err
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/ligato/cn-infra/logging"
+)
+
+// Default tunables of a Controller, used when the corresponding
+// ControllerConfig field is left empty.
+const (
+	DefaultWorkers        = 1
+	DefaultMinRetryPeriod = time.Second * 5
+	DefaultMaxRetryPeriod = time.Minute
+	DefaultMaxRetries     = 15
+)
+
+// Reconciler is implemented by the resource specific handlers driven by a
+// Controller.
+type Reconciler interface {
+	// Reconcile brings the object identified by namespace and name to its
+	// desired state. obj is the object in the informer cache, as returned by
+	// the Lookup of the controller, and must not be modified.
+	Reconcile(namespace, name string, obj interface{}) error
+	// Delete cleans up after the object identified by namespace and name,
+	// which can no longer be found in the informer cache. obj is the last
+	// known state of the object, or nil if it is not known.
+	Delete(namespace, name string, obj interface{}) error
+}
+
+// LookupFunc retrieves an object from the informer cache, typically by using
+// the lister of the resource. It must return a NotFound error when the object
+// does not exist.
+type LookupFunc func(namespace, name string) (interface{}, error)
+
+// ControllerConfig holds the parameters of a Controller.
+type ControllerConfig struct {
+	// Name identifies the controller in the logs.
+	Name string
+	// Informer delivers the events the controller reacts to.
+	Informer cache.SharedIndexInformer
+	// Lookup retrieves objects from the cache of the informer.
+	Lookup LookupFunc
+	// Reconciler handles the objects read off the queue.
+	Reconciler Reconciler
+	// Workers is the number of objects processed in parallel.
+	Workers int
+	// RateLimiter controls the backoff of objects which failed to reconcile.
+	// Defaults to an exponential backoff between DefaultMinRetryPeriod and
+	// DefaultMaxRetryPeriod.
+	RateLimiter workqueue.RateLimiter
+	// MaxRetries is the number of times an object failing to reconcile is
+	// retried before it is dropped from the queue, until its next event or
+	// resync. Defaults to DefaultMaxRetries.
+	MaxRetries int
+	// Filter selects the objects handled by the controller, all objects
+	// are handled when nil. It is given tombstones of deleted objects too.
+	Filter func(obj interface{}) bool
+	// WaitFor lists other caches the reconciler reads from, which have to be
+	// synced before the first object is processed.
+	WaitFor []cache.InformerSynced
+	// OnError is called with unrecoverable errors, i.e. errors which cannot
+	// be fixed by retrying.
+	OnError func(err error)
+	// Log is the logger used by the controller.
+	Log logging.Logger
+}
+
+// Controller feeds the events of an informer into a rate limited work queue
+// and runs a Reconciler for each object read off the queue. Objects which
+// fail to reconcile are requeued with a backoff.
+type Controller struct {
+	ControllerConfig
+
+	queue workqueue.RateLimitingInterface
+
+	// Last known state of deleted objects, kept until they are handed over
+	// to the reconciler successfully.
+	tombstoneLock sync.Mutex
+	tombstones    map[string]interface{}
+}
+
+// NewController creates a controller and registers its event handlers with
+// the informer. The controller does not process any object until Run is
+// called.
+func NewController(config ControllerConfig) *Controller {
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.RateLimiter == nil {
+		config.RateLimiter = workqueue.NewItemExponentialFailureRateLimiter(DefaultMinRetryPeriod, DefaultMaxRetryPeriod)
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = DefaultMaxRetries
+	}
+	if config.OnError == nil {
+		config.OnError = runtime.HandleError
+	}
+
+	c := &Controller{
+		ControllerConfig: config,
+		queue:            workqueue.NewNamedRateLimitingQueue(config.RateLimiter, config.Name),
+		tombstones:       make(map[string]interface{}),
+	}
+
+	// We add a new event handler, watching for changes to API resources.
+	// Updates are enqueued even when the object did not change, so that the
+	// periodic resyncs of the informer reconcile all the objects again.
+	config.Informer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.Enqueue,
+			UpdateFunc: func(old, cur interface{}) {
+				c.Enqueue(cur)
+			},
+			DeleteFunc: c.enqueueDeleted,
+		},
+	)
+
+	return c
+}
+
+// Enqueue adds an object into the work queue. The object must be of type
+// metav1.Object, metav1.ObjectAccessor, cache.ExplicitKey or
+// cache.DeletedFinalStateUnknown.
+func (c *Controller) Enqueue(obj interface{}) {
+	// DeletionHandlingMetaNamespaceKeyFunc will convert an object into a
+	// 'namespace/name' string. We do this because our item may be processed
+	// much later than now, and so we want to ensure it gets a fresh copy of
+	// the resource when it starts. Also, this allows us to keep adding the
+	// same item into the work queue without duplicates building up.
+	if c.Filter != nil && !c.Filter(obj) {
+		return
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueued: %s", err.Error()))
+		return
+	}
+	c.queue.Add(key)
+}
+
+// EnqueueKey adds the object identified by namespace and name into the work
+// queue.
+func (c *Controller) EnqueueKey(namespace, name string) {
+	c.queue.Add(objectKey(namespace, name))
+}
+
+// EnqueueKeyAfter adds the object identified by namespace and name into the
+// work queue once the delay has passed, e.g. to reconcile it again when a
+// deadline expires.
+func (c *Controller) EnqueueKeyAfter(namespace, name string, delay time.Duration) {
+	c.queue.AddAfter(objectKey(namespace, name), delay)
+}
+
+// Resync adds all the objects in the cache of the informer into the work
+// queue.
+func (c *Controller) Resync() {
+	for _, obj := range c.Informer.GetStore().List() {
+		c.Enqueue(obj)
+	}
+}
+
+// enqueueDeleted records the last known state of a deleted object and adds
+// it into the work queue. When the watch missed the delete event, the
+// informer hands over a tombstone with the last state it knew instead of the
+// object itself.
+func (c *Controller) enqueueDeleted(obj interface{}) {
+	if c.Filter != nil && !c.Filter(obj) {
+		return
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("error obtaining key for deleted object: %s", err.Error()))
+		return
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	c.tombstoneLock.Lock()
+	c.tombstones[key] = obj
+	c.tombstoneLock.Unlock()
+
+	c.queue.Add(key)
+}
+
+// HasSynced returns true once the cache of the informer has been populated.
+func (c *Controller) HasSynced() bool {
+	return c.Informer.HasSynced()
+}
+
+// Run waits for the caches to sync and processes the work queue with the
+// configured number of workers until stopCh is closed. It shuts down the
+// queue and waits for the workers to exit before returning.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	// Wait for the informer caches to finish performing their initial sync
+	// of resources
+	synced := append([]cache.InformerSynced{c.HasSynced}, c.WaitFor...)
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		c.Log.Errorf("Error waiting for %s informer cache to sync", c.Name)
+		c.queue.ShutDown()
+		return
+	}
+	c.Log.Infof("%s controller is ready, starting %d workers", c.Name, c.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Read from the work queue until it is shut down
+			for c.processNextItem() {
+			}
+		}()
+	}
+
+	<-stopCh
+	c.queue.ShutDown()
+	wg.Wait()
+	c.Log.Infof("%s controller stopped", c.Name)
+}
+
+// processNextItem reads a single item off the queue and processes it. It
+// returns false once the queue has been shut down.
+func (c *Controller) processNextItem() bool {
+	// We read a message off the queue ...
+	key, shutdown := c.queue.Get()
+
+	// If the queue has been shut down, we should exit the work queue here
+	if shutdown {
+		return false
+	}
+
+	// We make sure the message is marked as Done on the queue
+	defer c.queue.Done(key)
+
+	// Once the controller is stopping, only the items being processed are
+	// finished, the items left on the queue are dropped. They are enqueued
+	// again by the informer on the next start.
+	if c.queue.ShuttingDown() {
+		c.queue.Forget(key)
+		return true
+	}
+
+	// Convert the queue item into a string. If it's not a string, we'll
+	// simply discard it as invalid data and log a message.
+	strKey, ok := key.(string)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("key in queue should be of type string but got %T. discarding", key))
+		c.queue.Forget(key)
+		return true
+	}
+
+	if err := c.processKey(strKey); err != nil {
+		if c.queue.NumRequeues(key) >= c.MaxRetries {
+			// The object is reconciled again on its next event or resync
+			c.Log.Errorf("Error processing '%s', dropping it after %d retries: %s", strKey, c.MaxRetries, err.Error())
+			c.queue.Forget(key)
+			return true
+		}
+		c.Log.Errorf("Error processing '%s': %s", strKey, err.Error())
+		// This is a soft-error, we put the item back on the queue so it is
+		// retried with an exponential backoff
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	// As we managed to process this successfully, we can forget it from the
+	// work queue altogether.
+	c.queue.Forget(key)
+	return true
+}
+
+// processKey hands the object identified by key over to the reconciler.
+// Errors returned are retried, unrecoverable errors are reported to OnError.
+func (c *Controller) processKey(key string) error {
+	// Attempt to split the 'key' into namespace and object name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		// This is a soft-error, retrying would not help so we merely log it
+		c.Log.Errorf("Error splitting meta namespace key into parts: %s", err.Error())
+		return nil
+	}
+
+	c.Log.Debugf("Read item '%s' off %s workqueue. Processing...", key, c.Name)
+
+	// Retrieve the latest version in the cache of this object
+	obj, err := c.Lookup(namespace, name)
+	if apierrors.IsNotFound(err) {
+		// The object has been deleted since it was enqueued
+		c.tombstoneLock.Lock()
+		last := c.tombstones[key]
+		c.tombstoneLock.Unlock()
+
+		if err = c.Reconciler.Delete(namespace, name, last); err != nil {
+			return err
+		}
+
+		c.tombstoneLock.Lock()
+		delete(c.tombstones, key)
+		c.tombstoneLock.Unlock()
+
+		c.Log.Debugf("Finished cleaning up '%s' successfully! Removing from queue.", key)
+		return nil
+	}
+	if err != nil {
+		// This is a hard-error, the cache is not usable any more
+		c.OnError(fmt.Errorf("error getting object '%s' from cache: %s", key, err.Error()))
+		return nil
+	}
+
+	if err = c.Reconciler.Reconcile(namespace, name, obj); err != nil {
+		return err
+	}
+
+	// The object may have been deleted and created again before the delete
+	// was processed, its last known state is irrelevant now.
+	c.tombstoneLock.Lock()
+	delete(c.tombstones, key)
+	c.tombstoneLock.Unlock()
+
+	c.Log.Debugf("Finished processing '%s' successfully! Removing from queue.", key)
+	return nil
+}
+
+// objectKey returns the work queue key of the object identified by namespace
+// and name.
+func objectKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
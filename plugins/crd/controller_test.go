@@ -0,0 +1,388 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/ligato/cn-infra/logging"
+	"github.com/ligato/cn-infra/logging/logrus"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// fakeInformer is a SharedIndexInformer whose events are delivered by the
+// test.
+type fakeInformer struct {
+	indexer cache.Indexer
+	handler cache.ResourceEventHandler
+}
+
+func newFakeInformer() *fakeInformer {
+	return &fakeInformer{indexer: cache.NewIndexer(cache.DeletionHandlingMetaNamespaceKeyFunc, cache.Indexers{})}
+}
+
+func (i *fakeInformer) AddEventHandler(handler cache.ResourceEventHandler) { i.handler = handler }
+func (i *fakeInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.handler = handler
+}
+func (i *fakeInformer) GetStore() cache.Store           { return i.indexer }
+func (i *fakeInformer) GetController() cache.Controller { return nil }
+func (i *fakeInformer) Run(stopCh <-chan struct{})      { <-stopCh }
+func (i *fakeInformer) HasSynced() bool                 { return true }
+func (i *fakeInformer) LastSyncResourceVersion() string { return "" }
+func (i *fakeInformer) AddIndexers(indexers cache.Indexers) error {
+	return i.indexer.AddIndexers(indexers)
+}
+func (i *fakeInformer) GetIndexer() cache.Indexer { return i.indexer }
+
+func (i *fakeInformer) add(obj interface{}) {
+	i.indexer.Add(obj)
+	i.handler.OnAdd(obj)
+}
+
+func (i *fakeInformer) update(old, cur interface{}) {
+	i.indexer.Update(cur)
+	i.handler.OnUpdate(old, cur)
+}
+
+func (i *fakeInformer) delete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		i.indexer.Delete(tombstone.Obj)
+	} else {
+		i.indexer.Delete(obj)
+	}
+	i.handler.OnDelete(obj)
+}
+
+// lookup is the LookupFunc of the objects of the informer.
+func (i *fakeInformer) lookup(namespace, name string) (interface{}, error) {
+	obj, exists, err := i.indexer.GetByKey(objectKey(namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMPlural), name)
+	}
+	return obj, nil
+}
+
+// reconcileCall is a call of a Reconciler method recorded by fakeReconciler.
+type reconcileCall struct {
+	method string
+	key    string
+	obj    interface{}
+}
+
+// fakeReconciler records its calls. Calls fail while fail returns an error,
+// and block while block returns a channel which is not closed.
+type fakeReconciler struct {
+	sync.Mutex
+	calls []reconcileCall
+	fail  func(call reconcileCall) error
+	block func(call reconcileCall) <-chan struct{}
+}
+
+func (r *fakeReconciler) Reconcile(namespace, name string, obj interface{}) error {
+	return r.record(reconcileCall{"Reconcile", objectKey(namespace, name), obj})
+}
+
+func (r *fakeReconciler) Delete(namespace, name string, obj interface{}) error {
+	return r.record(reconcileCall{"Delete", objectKey(namespace, name), obj})
+}
+
+func (r *fakeReconciler) record(call reconcileCall) error {
+	r.Lock()
+	r.calls = append(r.calls, call)
+	fail, block := r.fail, r.block
+	r.Unlock()
+	if block != nil {
+		<-block(call)
+	}
+	if fail != nil {
+		return fail(call)
+	}
+	return nil
+}
+
+func (r *fakeReconciler) recorded() []reconcileCall {
+	r.Lock()
+	defer r.Unlock()
+	return append([]reconcileCall(nil), r.calls...)
+}
+
+// recordingRateLimiter records the backoff of the items it is asked for.
+type recordingRateLimiter struct {
+	workqueue.RateLimiter
+	sync.Mutex
+	delays []time.Duration
+}
+
+func (l *recordingRateLimiter) When(item interface{}) time.Duration {
+	delay := l.RateLimiter.When(item)
+	l.Lock()
+	l.delays = append(l.delays, delay)
+	l.Unlock()
+	return delay
+}
+
+func (l *recordingRateLimiter) recorded() []time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	return append([]time.Duration(nil), l.delays...)
+}
+
+// newTestController creates a controller on a fake informer and starts it.
+// stop stops the controller and waits for Run to return, it may be called
+// more than once.
+func newTestController(config ControllerConfig) (c *Controller, informer *fakeInformer, stop func()) {
+	informer = newFakeInformer()
+	config.Name = "Test"
+	config.Informer = informer
+	config.Lookup = informer.lookup
+	config.Log = logging.ForPlugin("controller-test", logrus.NewLogRegistry())
+	config.Log.SetLevel(logging.ErrorLevel)
+	c = NewController(config)
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		c.Run(stopCh)
+		close(done)
+	}()
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			close(stopCh)
+			<-done
+		})
+	}
+	return c, informer, stop
+}
+
+// eventually fails the test if condition is not met within a few seconds.
+func eventually(t *testing.T, what string, condition func() bool) {
+	deadline := time.Now().Add(time.Second * 5)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func testObject(name string) *v1.NetworkService {
+	return &v1.NetworkService{ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: "1"}}
+}
+
+func TestControllerRetriesWithBackoff(t *testing.T) {
+	tests := []struct {
+		name string
+		// failures is the number of times the object fails to reconcile
+		failures int
+		// calls is the number of calls of Reconcile expected
+		calls int
+	}{
+		{"success", 0, 1},
+		{"retried until success", 2, 3},
+		{"dropped after the maximum retries", 10, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := &recordingRateLimiter{
+				RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond*4),
+			}
+			reconciler := &fakeReconciler{}
+			failures := 0
+			reconciler.fail = func(call reconcileCall) error {
+				if failures < test.failures {
+					failures++
+					return fmt.Errorf("failure %d", failures)
+				}
+				return nil
+			}
+			c, informer, stop := newTestController(ControllerConfig{
+				Reconciler:  reconciler,
+				RateLimiter: limiter,
+				MaxRetries:  3,
+			})
+			defer stop()
+
+			informer.add(testObject("gold-network"))
+			eventually(t, "the object to be reconciled", func() bool {
+				return len(reconciler.recorded()) >= test.calls
+			})
+			// Leave time for unexpected retries
+			time.Sleep(time.Millisecond * 50)
+			stop()
+
+			if calls := reconciler.recorded(); len(calls) != test.calls {
+				t.Errorf("expected %d calls of Reconcile, got %d", test.calls, len(calls))
+			}
+			// The backoff doubles on each retry
+			var expected []time.Duration
+			for i, delay := 0, time.Millisecond; i < test.calls-1; i, delay = i+1, delay*2 {
+				expected = append(expected, delay)
+			}
+			if delays := limiter.recorded(); !reflect.DeepEqual(delays, expected) {
+				t.Errorf("expected the backoff %v, got %v", expected, delays)
+			}
+			// The object is forgotten once reconciled or dropped
+			if n := c.queue.NumRequeues("default/gold-network"); n != 0 {
+				t.Errorf("expected the object to be forgotten, got %d requeues", n)
+			}
+		})
+	}
+}
+
+func TestControllerReconcilesUpdates(t *testing.T) {
+	reconciler := &fakeReconciler{}
+	_, informer, stop := newTestController(ControllerConfig{Reconciler: reconciler})
+	defer stop()
+
+	obj := testObject("gold-network")
+	informer.add(obj)
+	eventually(t, "the object to be reconciled", func() bool { return len(reconciler.recorded()) == 1 })
+
+	// Resyncs deliver updates of unchanged objects, which are reconciled
+	// again
+	informer.update(obj, obj)
+	eventually(t, "the resync to be reconciled", func() bool { return len(reconciler.recorded()) == 2 })
+
+	changed := obj.DeepCopy()
+	changed.ResourceVersion = "2"
+	informer.update(obj, changed)
+	eventually(t, "the update to be reconciled", func() bool { return len(reconciler.recorded()) == 3 })
+	if call := reconciler.recorded()[2]; call.obj != changed {
+		t.Errorf("expected Reconcile of the updated object, got %v", call.obj)
+	}
+}
+
+func TestControllerDeletes(t *testing.T) {
+	tests := []struct {
+		name string
+		// deleted is handed over to the delete handler of the informer
+		deleted func(obj interface{}) interface{}
+	}{
+		{"delete", func(obj interface{}) interface{} { return obj }},
+		{"tombstone", func(obj interface{}) interface{} {
+			return cache.DeletedFinalStateUnknown{Key: "default/gold-network", Obj: obj}
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reconciler := &fakeReconciler{}
+			// The first Delete fails, the last known state is kept for
+			// the retry
+			failed := false
+			reconciler.fail = func(call reconcileCall) error {
+				if call.method == "Delete" && !failed {
+					failed = true
+					return fmt.Errorf("delete failed")
+				}
+				return nil
+			}
+			limiter := workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond)
+			c, informer, stop := newTestController(ControllerConfig{Reconciler: reconciler, RateLimiter: limiter})
+			defer stop()
+
+			obj := testObject("gold-network")
+			informer.add(obj)
+			eventually(t, "the object to be reconciled", func() bool { return len(reconciler.recorded()) == 1 })
+			informer.delete(test.deleted(obj))
+			eventually(t, "the object to be deleted", func() bool { return len(reconciler.recorded()) == 3 })
+
+			for _, call := range reconciler.recorded()[1:] {
+				if call.method != "Delete" || call.key != "default/gold-network" || call.obj != obj {
+					t.Errorf("expected Delete of the last known state, got %s of %s with %v", call.method, call.key, call.obj)
+				}
+			}
+			c.tombstoneLock.Lock()
+			defer c.tombstoneLock.Unlock()
+			if len(c.tombstones) != 0 {
+				t.Errorf("expected the last known state to be released, got %v", c.tombstones)
+			}
+		})
+	}
+
+	t.Run("unknown last state", func(t *testing.T) {
+		reconciler := &fakeReconciler{}
+		c, _, stop := newTestController(ControllerConfig{Reconciler: reconciler})
+		defer stop()
+		c.EnqueueKey("default", "gold-network")
+		eventually(t, "the object to be deleted", func() bool { return len(reconciler.recorded()) == 1 })
+		if call := reconciler.recorded()[0]; call.method != "Delete" || call.obj != nil {
+			t.Errorf("expected Delete without last known state, got %s with %v", call.method, call.obj)
+		}
+	})
+}
+
+func TestControllerWorkersDrainOnStop(t *testing.T) {
+	const workers = 3
+	release := make(chan struct{})
+	reconciler := &fakeReconciler{
+		block: func(call reconcileCall) <-chan struct{} { return release },
+	}
+	c, informer, stop := newTestController(ControllerConfig{Reconciler: reconciler, Workers: workers})
+	defer stop()
+
+	// Each worker picks an object up
+	for i := 0; i < workers; i++ {
+		informer.add(testObject(fmt.Sprintf("network-%d", i)))
+	}
+	eventually(t, "the workers to reconcile in parallel", func() bool { return len(reconciler.recorded()) == workers })
+	// The objects left on the queue once stopping are dropped
+	for i := workers; i < workers*2; i++ {
+		informer.add(testObject(fmt.Sprintf("network-%d", i)))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Run returned while objects were being reconciled")
+	case <-time.After(time.Millisecond * 100):
+	}
+	if !c.queue.ShuttingDown() {
+		t.Error("queue is not shut down once stopping")
+	}
+
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Run did not return once the reconciles finished")
+	}
+	if calls := reconciler.recorded(); len(calls) != workers {
+		t.Errorf("expected the %d objects being reconciled to finish and the others to be dropped, got %d calls", workers, len(calls))
+	}
+	if n := c.queue.Len(); n != 0 {
+		t.Errorf("expected the queue to be drained, got %d objects left", n)
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// API gives other plugins read access to the NSM objects cached by the CRD
+// plugin, without hitting the API server, and lets them publish endpoints and
+// connect to services on behalf of pods. The objects returned are shared
+// with the cache and must not be modified, use DeepCopy to get a modifiable
+// copy. An empty namespace stands for all the namespaces watched by the
+// plugin, objects in other namespaces are never returned. Get methods return
+// a NotFound error when the object does not exist.
+//
+// The API can be used once the CRD plugin has been initialized, the caches are
+// filled after its AfterInit. HasSynced returns true once they have been
+// populated.
+type API interface {
+	// HasSynced returns true once the caches have been populated.
+	HasSynced() bool
+
+	// GetNetworkService returns a NetworkService by namespace and name.
+	GetNetworkService(namespace, name string) (*v1.NetworkService, error)
+	// GetNetworkServiceByUUID returns the NetworkService with the given UUID.
+	GetNetworkServiceByUUID(uuid string) (*v1.NetworkService, error)
+	// ListNetworkServices returns the NetworkServices in a namespace.
+	ListNetworkServices(namespace string) ([]*v1.NetworkService, error)
+	// ListNetworkServicesUsingChannel returns the NetworkServices using a
+	// NetworkServiceChannel.
+	ListNetworkServicesUsingChannel(namespace, channel string) ([]*v1.NetworkService, error)
+	// ListNetworkServicesSelecting returns the NetworkServices in a
+	// namespace whose selector matches an endpoint with the given labels.
+	ListNetworkServicesSelecting(namespace string, endpointLabels map[string]string) ([]*v1.NetworkService, error)
+
+	// GetNetworkServiceChannel returns a NetworkServiceChannel by namespace
+	// and name.
+	GetNetworkServiceChannel(namespace, name string) (*v1.NetworkServiceChannel, error)
+	// ListNetworkServiceChannels returns the NetworkServiceChannels in a
+	// namespace.
+	ListNetworkServiceChannels(namespace string) ([]*v1.NetworkServiceChannel, error)
+
+	// GetNetworkServiceEndpoint returns a NetworkServiceEndpoint by namespace
+	// and name.
+	GetNetworkServiceEndpoint(namespace, name string) (*v1.NetworkServiceEndpoint, error)
+	// GetNetworkServiceEndpointByUUID returns the NetworkServiceEndpoint with
+	// the given UUID.
+	GetNetworkServiceEndpointByUUID(uuid string) (*v1.NetworkServiceEndpoint, error)
+	// ListNetworkServiceEndpoints returns the NetworkServiceEndpoints in a
+	// namespace.
+	ListNetworkServiceEndpoints(namespace string) ([]*v1.NetworkServiceEndpoint, error)
+	// ListNetworkServiceEndpointsOnNode returns the NetworkServiceEndpoints
+	// running on a node.
+	ListNetworkServiceEndpointsOnNode(node string) ([]*v1.NetworkServiceEndpoint, error)
+	// ListNetworkServiceEndpointsSelectedBy returns the
+	// NetworkServiceEndpoints selected by a NetworkService, in its namespace
+	// and through the services it imports.
+	ListNetworkServiceEndpointsSelectedBy(ns *v1.NetworkService) ([]*v1.NetworkServiceEndpoint, error)
+
+	// PublishEndpoint creates a NetworkServiceEndpoint owned by a pod, see
+	// crd_owner.go.
+	PublishEndpoint(namespace, podName string, spec *netmesh.NetworkServiceEndpoint, labels map[string]string) (*v1.NetworkServiceEndpoint, error)
+	// DelistEndpoint deletes the NetworkServiceEndpoint with the given UUID
+	// on behalf of the pod owning it.
+	DelistEndpoint(podNamespace, podName, uuid string) error
+	// RenewEndpoint records a heartbeat of the NetworkServiceEndpoint with
+	// the given UUID on behalf of the pod owning it, see crd_health.go.
+	RenewEndpoint(podNamespace, podName, uuid string) error
+	// HeartbeatGracePeriod returns the time after its last heartbeat an
+	// endpoint is marked unhealthy.
+	HeartbeatGracePeriod() time.Duration
+
+	// GetNetworkServiceConnection returns a NetworkServiceConnection by
+	// namespace and name.
+	GetNetworkServiceConnection(namespace, name string) (*v1.NetworkServiceConnection, error)
+	// GetNetworkServiceConnectionByUUID returns the NetworkServiceConnection
+	// with the given UUID.
+	GetNetworkServiceConnectionByUUID(uuid string) (*v1.NetworkServiceConnection, error)
+	// ListNetworkServiceConnections returns the NetworkServiceConnections in
+	// a namespace.
+	ListNetworkServiceConnections(namespace string) ([]*v1.NetworkServiceConnection, error)
+
+	// Connect creates a NetworkServiceConnection of a pod to an endpoint of
+	// a service, see crd_connection.go.
+	Connect(req *ConnectionRequest) (*v1.NetworkServiceConnection, error)
+	// Disconnect deletes the NetworkServiceConnection with the given UUID
+	// on behalf of the pod owning it.
+	Disconnect(podNamespace, podName, uuid string) error
+}
+
+// Compile time check that the plugin implements the API
+var _ API = &Plugin{}
+
+// HasSynced returns true once the caches have been populated.
+func (plugin *Plugin) HasSynced() bool {
+	informers := plugin.sharedFactory.Networkservice().V1()
+	return informers.NetworkServices().Informer().HasSynced() &&
+		informers.NetworkServiceChannels().Informer().HasSynced() &&
+		informers.NetworkServiceEndpoints().Informer().HasSynced() &&
+		informers.NetworkServiceConnections().Informer().HasSynced()
+}
+
+// GetNetworkService returns a NetworkService by namespace and name.
+func (plugin *Plugin) GetNetworkService(namespace, name string) (*v1.NetworkService, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMPlural), name)
+	}
+	return plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister().NetworkServices(namespace).Get(name)
+}
+
+// GetNetworkServiceByUUID returns the NetworkService with the given UUID.
+func (plugin *Plugin) GetNetworkServiceByUUID(uuid string) (*v1.NetworkService, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(uuidIndex, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMPlural), uuid)
+	}
+	if len(objs) > 1 {
+		return nil, fmt.Errorf("UUID %s is used by %d NetworkServices", uuid, len(objs))
+	}
+	ns := objs[0].(*v1.NetworkService)
+	if !plugin.watchesNamespace(ns.Namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMPlural), uuid)
+	}
+	return ns, nil
+}
+
+// ListNetworkServices returns the NetworkServices in a namespace.
+func (plugin *Plugin) ListNetworkServices(namespace string) ([]*v1.NetworkService, error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister()
+	var list []*v1.NetworkService
+	for _, ns := range plugin.listedNamespaces(namespace) {
+		objs, err := lister.NetworkServices(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, objs...)
+	}
+	return list, nil
+}
+
+// ListNetworkServicesUsingChannel returns the NetworkServices using a
+// NetworkServiceChannel.
+func (plugin *Plugin) ListNetworkServicesUsingChannel(namespace, channel string) ([]*v1.NetworkService, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, nil
+	}
+	return servicesUsingChannel(plugin, namespace, channel)
+}
+
+// ListNetworkServicesSelecting returns the NetworkServices in a namespace
+// whose selector matches an endpoint with the given labels.
+func (plugin *Plugin) ListNetworkServicesSelecting(namespace string, endpointLabels map[string]string) ([]*v1.NetworkService, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, nil
+	}
+	return servicesSelecting(plugin, namespace, endpointLabels)
+}
+
+// GetNetworkServiceChannel returns a NetworkServiceChannel by namespace and
+// name.
+func (plugin *Plugin) GetNetworkServiceChannel(namespace, name string) (*v1.NetworkServiceChannel, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMChannelPlural), name)
+	}
+	return plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Lister().NetworkServiceChannels(namespace).Get(name)
+}
+
+// ListNetworkServiceChannels returns the NetworkServiceChannels in a
+// namespace.
+func (plugin *Plugin) ListNetworkServiceChannels(namespace string) ([]*v1.NetworkServiceChannel, error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Lister()
+	var list []*v1.NetworkServiceChannel
+	for _, ns := range plugin.listedNamespaces(namespace) {
+		objs, err := lister.NetworkServiceChannels(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, objs...)
+	}
+	return list, nil
+}
+
+// GetNetworkServiceEndpoint returns a NetworkServiceEndpoint by namespace and
+// name.
+func (plugin *Plugin) GetNetworkServiceEndpoint(namespace, name string) (*v1.NetworkServiceEndpoint, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMEPPlural), name)
+	}
+	return plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Lister().NetworkServiceEndpoints(namespace).Get(name)
+}
+
+// GetNetworkServiceEndpointByUUID returns the NetworkServiceEndpoint with the
+// given UUID.
+func (plugin *Plugin) GetNetworkServiceEndpointByUUID(uuid string) (*v1.NetworkServiceEndpoint, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(uuidIndex, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMEPPlural), uuid)
+	}
+	if len(objs) > 1 {
+		return nil, fmt.Errorf("UUID %s is used by %d NetworkServiceEndpoints", uuid, len(objs))
+	}
+	nse := objs[0].(*v1.NetworkServiceEndpoint)
+	if !plugin.watchesNamespace(nse.Namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMEPPlural), uuid)
+	}
+	return nse, nil
+}
+
+// ListNetworkServiceEndpoints returns the NetworkServiceEndpoints in a
+// namespace.
+func (plugin *Plugin) ListNetworkServiceEndpoints(namespace string) ([]*v1.NetworkServiceEndpoint, error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Lister()
+	var list []*v1.NetworkServiceEndpoint
+	for _, ns := range plugin.listedNamespaces(namespace) {
+		objs, err := lister.NetworkServiceEndpoints(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, objs...)
+	}
+	return list, nil
+}
+
+// ListNetworkServiceEndpointsOnNode returns the NetworkServiceEndpoints
+// running on a node.
+func (plugin *Plugin) ListNetworkServiceEndpointsOnNode(node string) ([]*v1.NetworkServiceEndpoint, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(nodeIndex, node)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]*v1.NetworkServiceEndpoint, 0, len(objs))
+	for _, obj := range objs {
+		if plugin.watchesObject(obj) {
+			endpoints = append(endpoints, obj.(*v1.NetworkServiceEndpoint))
+		}
+	}
+	return endpoints, nil
+}
+
+// ListNetworkServiceEndpointsSelectedBy returns the NetworkServiceEndpoints
+// selected by a NetworkService, in its namespace and through the services it
+// imports. Endpoints being deleted or unhealthy are left out.
+func (plugin *Plugin) ListNetworkServiceEndpointsSelectedBy(ns *v1.NetworkService) ([]*v1.NetworkServiceEndpoint, error) {
+	if !plugin.watchesNamespace(ns.Namespace) {
+		return nil, nil
+	}
+	imported, state, message, err := resolveImports(plugin, ns)
+	if err != nil {
+		return nil, err
+	}
+	if state != "" {
+		return nil, fmt.Errorf("%s: %s", state, message)
+	}
+	return selectedEndpoints(plugin, ns, imported)
+}
+
+// GetNetworkServiceConnection returns a NetworkServiceConnection by namespace
+// and name.
+func (plugin *Plugin) GetNetworkServiceConnection(namespace, name string) (*v1.NetworkServiceConnection, error) {
+	if !plugin.watchesNamespace(namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMConnectionPlural), name)
+	}
+	return plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Lister().NetworkServiceConnections(namespace).Get(name)
+}
+
+// GetNetworkServiceConnectionByUUID returns the NetworkServiceConnection with
+// the given UUID.
+func (plugin *Plugin) GetNetworkServiceConnectionByUUID(uuid string) (*v1.NetworkServiceConnection, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(uuidIndex, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMConnectionPlural), uuid)
+	}
+	if len(objs) > 1 {
+		return nil, fmt.Errorf("UUID %s is used by %d NetworkServiceConnections", uuid, len(objs))
+	}
+	conn := objs[0].(*v1.NetworkServiceConnection)
+	if !plugin.watchesNamespace(conn.Namespace) {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMConnectionPlural), uuid)
+	}
+	return conn, nil
+}
+
+// ListNetworkServiceConnections returns the NetworkServiceConnections in a
+// namespace.
+func (plugin *Plugin) ListNetworkServiceConnections(namespace string) ([]*v1.NetworkServiceConnection, error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Lister()
+	var list []*v1.NetworkServiceConnection
+	for _, ns := range plugin.listedNamespaces(namespace) {
+		objs, err := lister.NetworkServiceConnections(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, objs...)
+	}
+	return list, nil
+}
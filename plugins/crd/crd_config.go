@@ -0,0 +1,276 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ligato/cn-infra/logging"
+)
+
+// DefaultResyncPeriod is the period at which the informers replay their
+// caches, in case any create/replace/update/delete operations are missed
+// when watching.
+const DefaultResyncPeriod = time.Second * 30
+
+// DefaultOrphanSweepPeriod is the period at which endpoints whose pod or node
+// no longer exists are looked for.
+const DefaultOrphanSweepPeriod = time.Minute
+
+// DefaultHeartbeatGracePeriod is the time after its last heartbeat an
+// endpoint is marked unhealthy.
+const DefaultHeartbeatGracePeriod = time.Second * 30
+
+// DefaultLogLevel is the log level of the plugin when none is configured.
+const DefaultLogLevel = "debug"
+
+// logLevels maps the log levels accepted in the configuration to the levels
+// of the logger.
+var logLevels = map[string]logging.LogLevel{
+	"debug":   logging.DebugLevel,
+	"info":    logging.InfoLevel,
+	"warning": logging.WarnLevel,
+	"warn":    logging.WarnLevel,
+	"error":   logging.ErrorLevel,
+	"fatal":   logging.FatalLevel,
+	"panic":   logging.PanicLevel,
+}
+
+// Config holds the tunables of the CRD plugin. It is read from the file
+// given by the netmeshcrd-config flag, fields left empty take their default
+// value. Durations are written as strings, e.g. "30s" or "1m".
+type Config struct {
+	// ResyncPeriod is the period at which all objects are reconciled again.
+	ResyncPeriod meta.Duration `json:"resync-period"`
+	// MinRetryPeriod and MaxRetryPeriod bound the exponential backoff of
+	// objects which failed to reconcile.
+	MinRetryPeriod meta.Duration `json:"min-retry-period"`
+	MaxRetryPeriod meta.Duration `json:"max-retry-period"`
+	// Workers is the number of objects of each resource reconciled in
+	// parallel.
+	Workers WorkersConfig `json:"workers"`
+	// Namespaces lists the namespaces watched by the plugin, all namespaces
+	// are watched when empty.
+	Namespaces []string `json:"namespaces"`
+	// LogLevel is the level of the logger of the plugin, one of debug, info,
+	// warning, error, fatal and panic.
+	LogLevel string `json:"log-level"`
+	// CRDsPreinstalled is set when the CRDs are installed along with the
+	// cluster, in which case the plugin only checks they exist instead of
+	// creating or updating them.
+	CRDsPreinstalled bool `json:"crds-preinstalled"`
+	// ShutdownTimeout bounds the time the plugin waits for in-flight
+	// reconciles when closed.
+	ShutdownTimeout meta.Duration `json:"shutdown-timeout"`
+	// OrphanSweepPeriod is the period at which endpoints whose pod or node
+	// no longer exists are deleted.
+	OrphanSweepPeriod meta.Duration `json:"orphan-sweep-period"`
+	// HeartbeatGracePeriod is the time after its last heartbeat an endpoint
+	// is marked unhealthy and no longer selected.
+	HeartbeatGracePeriod meta.Duration `json:"heartbeat-grace-period"`
+}
+
+// WorkersConfig holds the number of workers of each controller.
+type WorkersConfig struct {
+	NetworkServices           int `json:"network-services"`
+	NetworkServiceChannels    int `json:"network-service-channels"`
+	NetworkServiceEndpoints   int `json:"network-service-endpoints"`
+	NetworkServiceConnections int `json:"network-service-connections"`
+}
+
+// DefaultConfig returns the configuration used when no configuration file is
+// found.
+func DefaultConfig() *Config {
+	return &Config{
+		ResyncPeriod:   meta.Duration{Duration: DefaultResyncPeriod},
+		MinRetryPeriod: meta.Duration{Duration: DefaultMinRetryPeriod},
+		MaxRetryPeriod: meta.Duration{Duration: DefaultMaxRetryPeriod},
+		Workers: WorkersConfig{
+			NetworkServices:           DefaultWorkers,
+			NetworkServiceChannels:    DefaultWorkers,
+			NetworkServiceEndpoints:   DefaultWorkers,
+			NetworkServiceConnections: DefaultWorkers,
+		},
+		LogLevel:             DefaultLogLevel,
+		ShutdownTimeout:      meta.Duration{Duration: DefaultShutdownTimeout},
+		OrphanSweepPeriod:    meta.Duration{Duration: DefaultOrphanSweepPeriod},
+		HeartbeatGracePeriod: meta.Duration{Duration: DefaultHeartbeatGracePeriod},
+	}
+}
+
+// FixConfig fills the empty fields of the configuration with their default
+// value.
+func FixConfig(cfg *Config) {
+	defaults := DefaultConfig()
+	if cfg.ResyncPeriod.Duration == 0 {
+		cfg.ResyncPeriod = defaults.ResyncPeriod
+	}
+	if cfg.MinRetryPeriod.Duration == 0 {
+		cfg.MinRetryPeriod = defaults.MinRetryPeriod
+	}
+	if cfg.MaxRetryPeriod.Duration == 0 {
+		cfg.MaxRetryPeriod = defaults.MaxRetryPeriod
+	}
+	if cfg.Workers.NetworkServices == 0 {
+		cfg.Workers.NetworkServices = defaults.Workers.NetworkServices
+	}
+	if cfg.Workers.NetworkServiceChannels == 0 {
+		cfg.Workers.NetworkServiceChannels = defaults.Workers.NetworkServiceChannels
+	}
+	if cfg.Workers.NetworkServiceEndpoints == 0 {
+		cfg.Workers.NetworkServiceEndpoints = defaults.Workers.NetworkServiceEndpoints
+	}
+	if cfg.Workers.NetworkServiceConnections == 0 {
+		cfg.Workers.NetworkServiceConnections = defaults.Workers.NetworkServiceConnections
+	}
+	if cfg.LogLevel == "" {
+		cfg.LogLevel = defaults.LogLevel
+	}
+	if cfg.ShutdownTimeout.Duration == 0 {
+		cfg.ShutdownTimeout = defaults.ShutdownTimeout
+	}
+	if cfg.OrphanSweepPeriod.Duration == 0 {
+		cfg.OrphanSweepPeriod = defaults.OrphanSweepPeriod
+	}
+	if cfg.HeartbeatGracePeriod.Duration == 0 {
+		cfg.HeartbeatGracePeriod = defaults.HeartbeatGracePeriod
+	}
+}
+
+// Validate checks the configuration, once its defaults have been filled.
+func (cfg *Config) Validate() error {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"resync-period", cfg.ResyncPeriod.Duration},
+		{"min-retry-period", cfg.MinRetryPeriod.Duration},
+		{"max-retry-period", cfg.MaxRetryPeriod.Duration},
+		{"shutdown-timeout", cfg.ShutdownTimeout.Duration},
+		{"orphan-sweep-period", cfg.OrphanSweepPeriod.Duration},
+		{"heartbeat-grace-period", cfg.HeartbeatGracePeriod.Duration},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %s", d.name, d.value)
+		}
+	}
+	if cfg.MinRetryPeriod.Duration > cfg.MaxRetryPeriod.Duration {
+		return fmt.Errorf("min-retry-period %s is greater than max-retry-period %s",
+			cfg.MinRetryPeriod.Duration, cfg.MaxRetryPeriod.Duration)
+	}
+
+	workers := []struct {
+		name  string
+		value int
+	}{
+		{"network-services", cfg.Workers.NetworkServices},
+		{"network-service-channels", cfg.Workers.NetworkServiceChannels},
+		{"network-service-endpoints", cfg.Workers.NetworkServiceEndpoints},
+		{"network-service-connections", cfg.Workers.NetworkServiceConnections},
+	}
+	for _, w := range workers {
+		if w.value < 0 {
+			return fmt.Errorf("workers of %s must not be negative, got %d", w.name, w.value)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, namespace := range cfg.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+			return fmt.Errorf("invalid namespace '%s': %s", namespace, strings.Join(errs, ", "))
+		}
+		if seen[namespace] {
+			return fmt.Errorf("namespace '%s' is listed more than once", namespace)
+		}
+		seen[namespace] = true
+	}
+
+	if _, ok := logLevels[strings.ToLower(cfg.LogLevel)]; !ok {
+		return fmt.Errorf("unknown log level '%s'", cfg.LogLevel)
+	}
+
+	return nil
+}
+
+// loadConfig reads the configuration of the plugin, validates it and fills
+// in the defaults.
+func loadConfig(plugin *Plugin) (*Config, error) {
+	cfg := &Config{}
+	if plugin.PluginConfig != nil {
+		found, err := plugin.PluginConfig.GetValue(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error loading configuration: %s", err)
+		}
+		if !found {
+			plugin.Log.Debug("CRD plugin configuration not found, using defaults")
+		}
+	}
+	FixConfig(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %s", err)
+	}
+	return cfg, nil
+}
+
+// watchesNamespace returns true if the plugin watches the namespace.
+func (plugin *Plugin) watchesNamespace(namespace string) bool {
+	if len(plugin.config.Namespaces) == 0 {
+		return true
+	}
+	for _, watched := range plugin.config.Namespaces {
+		if namespace == watched {
+			return true
+		}
+	}
+	return false
+}
+
+// watchesObject returns true if the object, which can be a tombstone, is in
+// a namespace watched by the plugin.
+func (plugin *Plugin) watchesObject(obj interface{}) bool {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return false
+	}
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return false
+	}
+	return plugin.watchesNamespace(namespace)
+}
+
+// listedNamespaces returns the namespaces to list for a namespace given to
+// the API, where an empty namespace stands for all the watched namespaces.
+// An empty result means the namespace is not watched, while
+// meta.NamespaceAll is returned if all namespaces are watched.
+func (plugin *Plugin) listedNamespaces(namespace string) []string {
+	if namespace != meta.NamespaceAll {
+		if !plugin.watchesNamespace(namespace) {
+			return nil
+		}
+		return []string{namespace}
+	}
+	if len(plugin.config.Namespaces) == 0 {
+		return []string{meta.NamespaceAll}
+	}
+	return plugin.config.Namespaces
+}
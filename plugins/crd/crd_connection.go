@@ -0,0 +1,523 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/mechanism"
+)
+
+// This file contains the NetworkServiceConnections, which record the
+// connections of client pods to the endpoints of NetworkServices. Connections
+// are created on behalf of pods, in the namespace of the pod and owned by it,
+// so that the Kubernetes garbage collector deletes them along with the pod.
+// The leader records in their status whether their service, endpoint and
+// channel are still usable, along with their addresses, see crd_ipam.go, and
+// deletes them before their service or endpoint is deleted, see
+// crd_finalize.go.
+
+// ConnectionRequest describes a connection requested by a pod, see Connect.
+type ConnectionRequest struct {
+	// Namespace and PodName identify the client pod
+	Namespace string
+	PodName   string
+	// ServiceUUID is the UUID of the NetworkService, which must be in the
+	// namespace of the pod
+	ServiceUUID string
+	// Channel is the name of the channel of the service carrying the
+	// connection, it may be left empty for services with a single channel
+	Channel string
+	// Payload requested by the client, PAYLOAD_UNSPECIFIED accepts any
+	Payload netmesh.Payload
+	// Mechanisms accepted by the client, in order of preference
+	Mechanisms []*netmesh.LocalMechanism
+	// RemoteMechanisms accepted by the client to carry the connection to an
+	// endpoint on another node, in order of preference
+	RemoteMechanisms []*netmesh.RemoteMechanism
+	// Labels of the NetworkServiceConnection
+	Labels map[string]string
+}
+
+// Connect creates a NetworkServiceConnection of a pod to an endpoint of a
+// service. The endpoint is picked among the endpoints the service selects,
+// see selectEndpoint, and the connection is owned by the pod. A
+// ServiceUnavailable error is returned when no endpoint can accept the
+// connection.
+func (plugin *Plugin) Connect(req *ConnectionRequest) (*v1.NetworkServiceConnection, error) {
+	if !plugin.watchesNamespace(req.Namespace) {
+		return nil, apierrors.NewForbidden(v1.Resource(v1.NSMConnectionPlural), req.PodName, fmt.Errorf("namespace '%s' is not watched", req.Namespace))
+	}
+	ns, err := plugin.GetNetworkServiceByUUID(req.ServiceUUID)
+	if err != nil {
+		return nil, err
+	}
+	// Services of other namespaces are reached by importing them
+	if ns.Namespace != req.Namespace {
+		return nil, apierrors.NewNotFound(v1.Resource(v1.NSMPlural), req.ServiceUUID)
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil, apierrors.NewConflict(v1.Resource(v1.NSMPlural), ns.Name, fmt.Errorf("service is being deleted"))
+	}
+	pod, err := plugin.k8sClientset.CoreV1().Pods(req.Namespace).Get(req.PodName, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.DeletionTimestamp != nil {
+		return nil, apierrors.NewConflict(corev1.Resource("pods"), req.PodName, fmt.Errorf("pod is being deleted"))
+	}
+	for _, m := range req.Mechanisms {
+		if m == nil {
+			continue
+		}
+		if err = m.Validate(); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+	for _, m := range req.RemoteMechanisms {
+		if m == nil {
+			continue
+		}
+		if err = m.Validate(); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+
+	channel, _, problem, err := connectionChannel(plugin, ns, req.Channel, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if problem != "" {
+		return nil, apierrors.NewBadRequest(problem)
+	}
+	nse, negotiated, remote, err := selectEndpoint(plugin, ns, pod.Spec.NodeName, req.Mechanisms, req.RemoteMechanisms)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &v1.NetworkServiceConnection{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace:       req.Namespace,
+			Labels:          make(map[string]string, len(req.Labels)),
+			OwnerReferences: []meta.OwnerReference{podOwnerReference(pod)},
+		},
+		Spec: netmesh.NetworkServiceConnection{
+			Client:         &netmesh.PodReference{Name: pod.Name, Uid: string(pod.UID)},
+			NetworkService: ns.Name,
+			Endpoint: &netmesh.EndpointReference{
+				Namespace: nse.Namespace,
+				Name:      nse.Name,
+				Uuid:      nse.Spec.Uuid,
+			},
+			Channel:         channel,
+			Payload:         req.Payload.Name(),
+			Mechanism:       negotiated,
+			RemoteMechanism: remote,
+		},
+	}
+	for key, value := range req.Labels {
+		conn.Labels[key] = value
+	}
+	assignUUID(&conn.Spec.Uuid, "")
+	conn.Name = generatedName(pod.Name, conn.Spec.Uuid)
+
+	created, err := plugin.crdClient.NetworkserviceV1().NetworkServiceConnections(req.Namespace).Create(conn)
+	if err != nil {
+		return nil, err
+	}
+	plugin.Log.Infof("Pod '%s/%s' connected to NetworkServiceEndpoint '%s/%s' through NetworkService '%s' with UUID %s",
+		req.Namespace, req.PodName, nse.Namespace, nse.Name, ns.Name, created.Spec.Uuid)
+	return created, nil
+}
+
+// Disconnect deletes the NetworkServiceConnection with the given UUID on
+// behalf of the pod owning it.
+func (plugin *Plugin) Disconnect(podNamespace, podName, uuid string) error {
+	conn, err := plugin.GetNetworkServiceConnectionByUUID(uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkPodOwner(plugin, v1.NSMConnectionPlural, &conn.ObjectMeta, uuid, podNamespace, podName); err != nil {
+		return err
+	}
+	err = plugin.crdClient.NetworkserviceV1().NetworkServiceConnections(conn.Namespace).Delete(conn.Name, &meta.DeleteOptions{
+		Preconditions: &meta.Preconditions{UID: &conn.UID},
+	})
+	if err != nil {
+		return err
+	}
+	plugin.Log.Infof("Deleted NetworkServiceConnection '%s/%s'", conn.Namespace, conn.Name)
+	return nil
+}
+
+// connectionChannel resolves the channel of a service carrying a connection,
+// given the name of the channel requested, which may be empty for services
+// with a single channel, and the payload requested. It returns the name of the
+// channel and how it carries the payload. The channel is empty for services
+// without channels. problem describes why the channel cannot carry the
+// connection, err is only returned when the caches cannot be read.
+func connectionChannel(plugin *Plugin, ns *v1.NetworkService, name string, payload netmesh.Payload) (channel string, adaptation netmesh.PayloadAdaptation, problem string, err error) {
+	var names []string
+	for _, c := range ns.Spec.Channels {
+		if c != nil {
+			names = append(names, c.Name)
+		}
+	}
+	switch {
+	case len(names) == 0:
+		if name != "" {
+			return "", netmesh.PayloadAdaptationNone, fmt.Sprintf("service %s has no channels", ns.Name), nil
+		}
+		return "", netmesh.PayloadAdaptationNone, "", nil
+	case name == "" && len(names) == 1:
+		name = names[0]
+	case name == "":
+		sort.Strings(names)
+		return "", netmesh.PayloadAdaptationNone,
+			fmt.Sprintf("a channel is required, service %s has channels %s", ns.Name, strings.Join(names, ", ")), nil
+	}
+
+	found := false
+	for _, n := range names {
+		found = found || n == name
+	}
+	if !found {
+		return "", netmesh.PayloadAdaptationNone, fmt.Sprintf("service %s has no channel %s", ns.Name, name), nil
+	}
+	nsc, err := plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Lister().NetworkServiceChannels(ns.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return "", netmesh.PayloadAdaptationNone, fmt.Sprintf("channel %s not found", name), nil
+	} else if err != nil {
+		return "", netmesh.PayloadAdaptationNone, "", err
+	}
+	adaptation, err = nsc.Spec.Adapt(payload)
+	if err != nil {
+		return "", netmesh.PayloadAdaptationNone, err.Error(), nil
+	}
+	return name, adaptation, "", nil
+}
+
+// selectEndpoint picks the endpoint of a connection through a service among
+// the endpoints the service selects, along with the mechanism plugging the
+// connection into the client, see mechanism.NegotiateLocal. For endpoints on
+// another node than the client, the mechanism carrying the connection between
+// the nodes is negotiated as well, see mechanism.NegotiateRemote; it is nil
+// on the same node or when the node of either is unknown, see endpointNode.
+// Endpoints at capacity or supporting none of the mechanisms of the client are
+// skipped. The endpoint with the fewest connections is picked, ties are broken
+// by namespace and name so that the choice is deterministic. The connections
+// are counted in the informer cache as by connectionOverCapacity, connections
+// overbooking an endpoint fail once reconciled.
+func selectEndpoint(plugin *Plugin, ns *v1.NetworkService, node string, mechanisms []*netmesh.LocalMechanism, remoteMechanisms []*netmesh.RemoteMechanism) (*v1.NetworkServiceEndpoint, *netmesh.LocalMechanism, *netmesh.RemoteMechanism, error) {
+	endpoints, err := plugin.ListNetworkServiceEndpointsSelectedBy(ns)
+	if err != nil {
+		return nil, nil, nil, apierrors.NewServiceUnavailable(fmt.Sprintf("service %s cannot select endpoints: %s", ns.Name, err))
+	}
+
+	var (
+		selected   *v1.NetworkServiceEndpoint
+		negotiated *netmesh.LocalMechanism
+		remote     *netmesh.RemoteMechanism
+		key        string
+		load       int
+	)
+	for _, nse := range endpoints {
+		m, err := mechanism.NegotiateLocal(mechanisms, nse.Spec.Mechanisms)
+		if err != nil {
+			continue
+		}
+		var r *netmesh.RemoteMechanism
+		if nseNode := endpointNode(nse); node != "" && nseNode != "" && node != nseNode {
+			r, err = mechanism.NegotiateRemote(remoteMechanisms, nse.Spec.RemoteMechanisms)
+			if err != nil {
+				continue
+			}
+		}
+		k := objectKey(nse.Namespace, nse.Name)
+		connections, err := endpointConnections(plugin, nse)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		n := len(connections)
+		if nse.Spec.Capacity > 0 && n >= int(nse.Spec.Capacity) {
+			continue
+		}
+		if selected == nil || n < load || (n == load && k < key) {
+			selected, negotiated, remote, key, load = nse, m, r, k, n
+		}
+	}
+	if selected == nil {
+		return nil, nil, nil, apierrors.NewServiceUnavailable(fmt.Sprintf("no endpoint of service %s can accept the connection", ns.Name))
+	}
+	return selected, negotiated, remote, nil
+}
+
+// endpointConnections returns the connections counting towards the capacity
+// of an endpoint, from the informer cache. Connections being deleted do not
+// count, nor do the connections to an endpoint replaced since, which fail
+// anyway.
+func endpointConnections(plugin *Plugin, nse *v1.NetworkServiceEndpoint) ([]*v1.NetworkServiceConnection, error) {
+	connections, err := connectionsByIndex(plugin, endpointIndex, objectKey(nse.Namespace, nse.Name))
+	if err != nil {
+		return nil, err
+	}
+	counted := connections[:0]
+	for _, conn := range connections {
+		if conn.DeletionTimestamp != nil {
+			continue
+		}
+		if ref := conn.Spec.Endpoint; ref == nil || (ref.Uuid != "" && ref.Uuid != nse.Spec.Uuid) {
+			continue
+		}
+		counted = append(counted, conn)
+	}
+	return counted, nil
+}
+
+// connectionOverCapacity returns true if a connection exceeds the capacity of
+// its endpoint. Connect counts the connections of the endpoints in the
+// informer cache, which misses the connections being created concurrently or
+// not yet seen, so an endpoint may be overbooked. The connections to an
+// endpoint are ranked by creation time, then by namespace and name, and those
+// ranked beyond the capacity fail, see endpointConnections for the
+// connections which count.
+func connectionOverCapacity(plugin *Plugin, nse *v1.NetworkServiceEndpoint, conn *v1.NetworkServiceConnection) (bool, error) {
+	if nse.Spec.Capacity == 0 {
+		return false, nil
+	}
+	connections, err := endpointConnections(plugin, nse)
+	if err != nil {
+		return false, err
+	}
+	rank := 0
+	for _, other := range connections {
+		if other.UID == conn.UID {
+			continue
+		}
+		if connectionBefore(other, conn) {
+			rank++
+		}
+	}
+	return rank >= int(nse.Spec.Capacity), nil
+}
+
+// connectionBefore orders connections by creation time, then by namespace and
+// name.
+func connectionBefore(a, b *v1.NetworkServiceConnection) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return objectKey(a.Namespace, a.Name) < objectKey(b.Namespace, b.Name)
+}
+
+// reconcileNetworkServiceConnection makes sure a NetworkServiceConnection
+// carries its UUID and records in its status whether its service, endpoint and
+// channel are usable. The object passed in comes from the informer cache and
+// must not be modified.
+func reconcileNetworkServiceConnection(plugin *Plugin, conn *v1.NetworkServiceConnection) error {
+	if !plugin.IsLeader() {
+		plugin.Log.Debugf("Not the leader, skipping status of '%s/%s'", conn.Namespace, conn.Name)
+		return nil
+	}
+	if conn.DeletionTimestamp != nil {
+		return nil
+	}
+	if updated, err := initializeNetworkServiceConnection(plugin, conn); err != nil || updated {
+		return err
+	}
+
+	status, err := connectionStatus(plugin, conn)
+	if err != nil {
+		return err
+	}
+	// Failed connections keep their addresses, they are released along with
+	// the connection
+	if status.State != v1.NetworkServiceConnectionStateEstablished {
+		status.Addresses = conn.Status.Addresses
+	}
+	status.Conditions = v1.SetCondition(conn.Status.Conditions, readyCondition(conn.Generation, status.State, status.Message))
+	status.UUID = conn.Status.UUID
+
+	if reflect.DeepEqual(conn.Status, status) {
+		plugin.Log.Debugf("Status of '%s/%s' is up to date: %s", conn.Namespace, conn.Name, status.State)
+		return nil
+	}
+
+	connCopy := conn.DeepCopy()
+	connCopy.Status = status
+	if _, err = plugin.crdClient.NetworkserviceV1().NetworkServiceConnections(conn.Namespace).UpdateStatus(connCopy); err != nil {
+		return fmt.Errorf("error updating status of '%s/%s': %s", conn.Namespace, conn.Name, err)
+	}
+	plugin.Log.Infof("NetworkServiceConnection '%s/%s' is %s: %s", conn.Namespace, conn.Name, status.State, status.Message)
+	recordStateChange(plugin, conn, conn.Status.State, status.State, status.Message)
+	if status.State == v1.NetworkServiceConnectionStateEstablished && conn.Status.State != status.State {
+		recordConnectionEvent(plugin, conn, EventReasonConnectionEstablished, "Pod %s connected to endpoint %s")
+	}
+
+	return nil
+}
+
+// connectionStatus computes the status of a NetworkServiceConnection from the
+// service, the endpoint and the channel currently present in the informer
+// caches.
+func connectionStatus(plugin *Plugin, conn *v1.NetworkServiceConnection) (v1.NetworkServiceConnectionStatus, error) {
+	failed := func(format string, args ...interface{}) (v1.NetworkServiceConnectionStatus, error) {
+		return v1.NetworkServiceConnectionStatus{
+			State:   v1.NetworkServiceConnectionStateFailed,
+			Message: fmt.Sprintf(format, args...),
+		}, nil
+	}
+
+	ns, err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister().NetworkServices(conn.Namespace).Get(conn.Spec.NetworkService)
+	if apierrors.IsNotFound(err) {
+		return failed("service %s not found", conn.Spec.NetworkService)
+	} else if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if ns.DeletionTimestamp != nil {
+		return failed("service %s is being deleted", ns.Name)
+	}
+
+	// The endpoint must still be selected by the service, possibly through
+	// the services it imports
+	ref := conn.Spec.Endpoint
+	if ref == nil {
+		return failed("no endpoint")
+	}
+	nse, err := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Lister().NetworkServiceEndpoints(ref.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		return failed("endpoint %s/%s not found", ref.Namespace, ref.Name)
+	} else if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if ref.Uuid != "" && ref.Uuid != nse.Spec.Uuid {
+		return failed("endpoint %s/%s has been replaced", ref.Namespace, ref.Name)
+	}
+	if nse.DeletionTimestamp != nil {
+		return failed("endpoint %s/%s is being deleted", ref.Namespace, ref.Name)
+	}
+	if !endpointSelectable(nse) {
+		return failed("endpoint %s/%s is %s: %s", ref.Namespace, ref.Name, nse.Status.State, nse.Status.Message)
+	}
+	imported, state, message, err := resolveImports(plugin, ns)
+	if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if state != "" {
+		return failed("service %s is %s: %s", ns.Name, state, message)
+	}
+	endpoints, err := selectedEndpoints(plugin, ns, imported)
+	if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	selected := false
+	for _, endpoint := range endpoints {
+		selected = selected || endpoint.UID == nse.UID
+	}
+	if !selected {
+		return failed("endpoint %s/%s is no longer selected by service %s", ref.Namespace, ref.Name, ns.Name)
+	}
+	over, err := connectionOverCapacity(plugin, nse, conn)
+	if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if over {
+		return failed("endpoint %s/%s is at capacity (%d connections)", ref.Namespace, ref.Name, nse.Spec.Capacity)
+	}
+
+	payload, err := netmesh.ParsePayload(conn.Spec.Payload)
+	if err != nil {
+		return failed("%s", err)
+	}
+	channel, adaptation, problem, err := connectionChannel(plugin, ns, conn.Spec.Channel, payload)
+	if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if problem != "" {
+		return failed("%s", problem)
+	}
+
+	addresses, problem, err := connectionAddresses(plugin, ns, conn)
+	if err != nil {
+		return v1.NetworkServiceConnectionStatus{}, err
+	}
+	if problem != "" {
+		return failed("%s", problem)
+	}
+
+	status := v1.NetworkServiceConnectionStatus{
+		State:             v1.NetworkServiceConnectionStateEstablished,
+		Message:           fmt.Sprintf("connected to endpoint %s/%s", ref.Namespace, ref.Name),
+		PayloadAdaptation: string(adaptation),
+		Addresses:         addresses,
+	}
+	if channel != "" {
+		status.Message += fmt.Sprintf(" through channel %s", channel)
+	}
+	if addresses != nil {
+		status.Message += fmt.Sprintf(" with address %s", addresses.Client)
+	}
+	return status, nil
+}
+
+// recordConnectionEvent records an event about a connection on its service,
+// if the service still exists. format is given the name of the client pod and
+// the endpoint of the connection.
+func recordConnectionEvent(plugin *Plugin, conn *v1.NetworkServiceConnection, reason, format string) {
+	ns, err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister().NetworkServices(conn.Namespace).Get(conn.Spec.NetworkService)
+	if err != nil {
+		return
+	}
+	var client, endpoint string
+	if conn.Spec.Client != nil {
+		client = conn.Spec.Client.Name
+	}
+	if ref := conn.Spec.Endpoint; ref != nil {
+		endpoint = objectKey(ref.Namespace, ref.Name)
+	}
+	plugin.recorder.Eventf(ns, corev1.EventTypeNormal, reason, format, client, endpoint)
+}
+
+// deleteConnectionsHook returns the finalize hook deleting the connections
+// through a service or to an endpoint being deleted, given the index of the
+// connections by service or by endpoint.
+func deleteConnectionsHook(plugin *Plugin, index string) FinalizeHook {
+	return func(namespace, name string, obj interface{}) error {
+		connections, err := connectionsByIndex(plugin, index, objectKey(namespace, name))
+		if err != nil {
+			return err
+		}
+		for _, conn := range connections {
+			err = plugin.crdClient.NetworkserviceV1().NetworkServiceConnections(conn.Namespace).Delete(conn.Name, &meta.DeleteOptions{
+				Preconditions: &meta.Preconditions{UID: &conn.UID},
+			})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error deleting NetworkServiceConnection '%s/%s': %s", conn.Namespace, conn.Name, err)
+			}
+			plugin.Log.Infof("Deleted NetworkServiceConnection '%s/%s' through %s '%s/%s'",
+				conn.Namespace, conn.Name, index, namespace, name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+func TestConnectionOverCapacity(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().GetIndexer()
+
+	nse := &v1.NetworkServiceEndpoint{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-endpoint"},
+		Spec:       netmesh.NetworkServiceEndpoint{Uuid: "endpoint-uuid", Capacity: 2},
+	}
+	created := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+	connection := func(name string, age time.Duration) *v1.NetworkServiceConnection {
+		conn := &v1.NetworkServiceConnection{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				UID:               types.UID(name),
+				CreationTimestamp: meta.NewTime(created.Add(-age)),
+			},
+			Spec: netmesh.NetworkServiceConnection{
+				Endpoint: &netmesh.EndpointReference{Namespace: nse.Namespace, Name: nse.Name, Uuid: nse.Spec.Uuid},
+			},
+		}
+		if err := indexer.Add(conn); err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	oldest := connection("oldest", time.Minute*2)
+	tiedB := connection("tied-b", time.Minute)
+	tiedA := connection("tied-a", time.Minute)
+	newest := connection("newest", 0)
+	// Connections being deleted or to a replaced endpoint do not count
+	deleted := connection("deleted", time.Hour)
+	now := meta.Now()
+	deleted.DeletionTimestamp = &now
+	replaced := connection("replaced", time.Hour)
+	replaced.Spec.Endpoint.Uuid = "replaced-uuid"
+	for _, conn := range []*v1.NetworkServiceConnection{deleted, replaced} {
+		if err := indexer.Update(conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		conn *v1.NetworkServiceConnection
+		over bool
+	}{
+		{oldest, false},
+		{tiedA, false},
+		{tiedB, true},
+		{newest, true},
+	}
+	for _, test := range tests {
+		t.Run(test.conn.Name, func(t *testing.T) {
+			over, err := connectionOverCapacity(plugin, nse, test.conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if over != test.over {
+				t.Errorf("expected over capacity to be %t, got %t", test.over, over)
+			}
+		})
+	}
+
+	// Without capacity, an endpoint accepts any number of connections
+	unlimited := nse.DeepCopy()
+	unlimited.Spec.Capacity = 0
+	for i := 0; i < 3; i++ {
+		connection(fmt.Sprintf("extra-%d", i), 0)
+	}
+	if over, err := connectionOverCapacity(plugin, unlimited, newest); err != nil || over {
+		t.Errorf("expected an endpoint without capacity to accept the connection, got %t, %v", over, err)
+	}
+}
+
+func TestSelectEndpointNegotiatesRemoteMechanism(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer()
+	endpoint := func(name, node string, remoteMechanisms ...string) {
+		nse := &v1.NetworkServiceEndpoint{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: name},
+			Spec: netmesh.NetworkServiceEndpoint{
+				Uuid:             name + "-uuid",
+				Node:             node,
+				RemoteMechanisms: remoteMechanisms,
+			},
+		}
+		if err := indexer.Add(nse); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ns := testService()
+	vxlan := &netmesh.RemoteMechanism{Type: netmesh.MechanismNameVXLAN}
+	gre := &netmesh.RemoteMechanism{Type: netmesh.MechanismNameGRE}
+
+	// The endpoint on another node only supports GRE
+	endpoint("a-remote", "node-b", netmesh.MechanismNameGRE)
+
+	tests := []struct {
+		name     string
+		node     string
+		remote   []*netmesh.RemoteMechanism
+		endpoint string
+		expected *netmesh.RemoteMechanism
+	}{
+		{"client on another node", "node-a", []*netmesh.RemoteMechanism{vxlan, gre}, "a-remote", gre},
+		{"client without remote mechanisms", "node-a", nil, "a-remote", gre},
+		{"client on the node of the endpoint", "node-b", []*netmesh.RemoteMechanism{vxlan}, "a-remote", nil},
+		{"client on an unknown node", "", []*netmesh.RemoteMechanism{vxlan}, "a-remote", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nse, _, remote, err := selectEndpoint(plugin, ns, test.node, nil, test.remote)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nse.Name != test.endpoint {
+				t.Errorf("expected endpoint %s, got %s", test.endpoint, nse.Name)
+			}
+			if !reflect.DeepEqual(remote, test.expected) {
+				t.Errorf("expected remote mechanism %v, got %v", test.expected, remote)
+			}
+		})
+	}
+
+	// Endpoints supporting none of the remote mechanisms of the client are
+	// skipped
+	if _, _, _, err := selectEndpoint(plugin, ns, "node-a", nil, []*netmesh.RemoteMechanism{vxlan}); !apierrors.IsServiceUnavailable(err) {
+		t.Fatalf("expected a ServiceUnavailable error, got %v", err)
+	}
+	endpoint("b-local", "node-a")
+	nse, _, remote, err := selectEndpoint(plugin, ns, "node-a", nil, []*netmesh.RemoteMechanism{vxlan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nse.Name != "b-local" || remote != nil {
+		t.Errorf("expected the endpoint on the node of the client without remote mechanism, got %s, %v", nse.Name, remote)
+	}
+}
+
+func TestSelectEndpointCountsConnections(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	// The node of the endpoint is only known from its label
+	nse := &v1.NetworkServiceEndpoint{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: "default",
+			Name:      "gold-endpoint",
+			Labels:    map[string]string{v1.NSMNodeLabel: "node-b"},
+		},
+		Spec: netmesh.NetworkServiceEndpoint{
+			Uuid:             "endpoint-uuid",
+			Capacity:         1,
+			RemoteMechanisms: []string{netmesh.MechanismNameGRE},
+		},
+	}
+	if err := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer().Add(nse); err != nil {
+		t.Fatal(err)
+	}
+
+	// Connections being deleted or to a replaced endpoint do not count
+	connIndexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().GetIndexer()
+	now := meta.Now()
+	for _, uuid := range []string{nse.Spec.Uuid, "replaced-uuid"} {
+		conn := &v1.NetworkServiceConnection{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: uuid + "-connection"},
+			Spec: netmesh.NetworkServiceConnection{
+				Endpoint: &netmesh.EndpointReference{Namespace: nse.Namespace, Name: nse.Name, Uuid: uuid},
+			},
+		}
+		if uuid == nse.Spec.Uuid {
+			conn.DeletionTimestamp = &now
+		}
+		if err := connIndexer.Add(conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gre := &netmesh.RemoteMechanism{Type: netmesh.MechanismNameGRE}
+	selected, _, remote, err := selectEndpoint(plugin, testService(), "node-a", nil, []*netmesh.RemoteMechanism{gre})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selected.Name != nse.Name || !reflect.DeepEqual(remote, gre) {
+		t.Errorf("expected endpoint %s over GRE, got %s, %v", nse.Name, selected.Name, remote)
+	}
+}
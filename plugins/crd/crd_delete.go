@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the handling of deleted objects. The controllers hand
+// over the keys of objects which can no longer be found in the cache to the
+// delete handler of the resource, together with their last known state.
+
+// DeleteHook is a cleanup function run when a NetworkService, a
+// NetworkServiceChannel, a NetworkServiceEndpoint or a
+// NetworkServiceConnection is deleted. obj is the last
+// known state of the deleted object, or nil if it is not known. Returning an
+// error retries the hook later with an exponential backoff.
+type DeleteHook func(namespace, name string, obj interface{}) error
+
+// RegisterDeleteHook registers a cleanup hook for one of the NSM resources,
+// identified by its plural name (v1.NSMPlural, v1.NSMChannelPlural,
+// v1.NSMEPPlural or v1.NSMConnectionPlural).
+func (plugin *Plugin) RegisterDeleteHook(resource string, hook DeleteHook) {
+	plugin.deleteLock.Lock()
+	defer plugin.deleteLock.Unlock()
+
+	plugin.deleteHooks[resource] = append(plugin.deleteHooks[resource], hook)
+}
+
+// runDeleteHooks runs all the hooks registered for a resource.
+func runDeleteHooks(plugin *Plugin, resource, namespace, name string, obj interface{}) error {
+	plugin.deleteLock.Lock()
+	hooks := append([]DeleteHook(nil), plugin.deleteHooks[resource]...)
+	plugin.deleteLock.Unlock()
+
+	for _, hook := range hooks {
+		if err := hook(namespace, name, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkserviceDeleted is the delete handler for NetworkServices. Connections
+// through the service are requeued.
+func networkserviceDeleted(plugin *Plugin, namespace, name string, obj interface{}) error {
+	plugin.Log.Infof("NetworkService '%s/%s' has been deleted. Cleaning up...", namespace, name)
+	if err := runDeleteHooks(plugin, v1.NSMPlural, namespace, name, obj); err != nil {
+		return err
+	}
+
+	dropAddressPool(plugin, namespace, name)
+	requeueConnections(plugin, serviceIndex, namespace, name)
+	return nil
+}
+
+// networkservicechannelDeleted is the delete handler for
+// NetworkServiceChannels. Services using the channel are requeued, so that
+// their status reflects the missing channel, along with their connections.
+func networkservicechannelDeleted(plugin *Plugin, namespace, name string, obj interface{}) error {
+	plugin.Log.Infof("NetworkServiceChannel '%s/%s' has been deleted. Cleaning up...", namespace, name)
+	if err := runDeleteHooks(plugin, v1.NSMChannelPlural, namespace, name, obj); err != nil {
+		return err
+	}
+
+	requeueConnectionsUsingChannel(plugin, namespace, name)
+	return requeueServicesUsingChannel(plugin, namespace, name)
+}
+
+// networkserviceendpointDeleted is the delete handler for
+// NetworkServiceEndpoints. Services selecting the endpoint, along with the
+// services importing them, and connections to the endpoint are requeued. All
+// services in the namespace and their importers are requeued when the last
+// state of the endpoint is not known, as any of them may have selected it.
+func networkserviceendpointDeleted(plugin *Plugin, namespace, name string, obj interface{}) error {
+	plugin.Log.Infof("NetworkServiceEndpoint '%s/%s' has been deleted. Cleaning up...", namespace, name)
+	if err := runDeleteHooks(plugin, v1.NSMEPPlural, namespace, name, obj); err != nil {
+		return err
+	}
+	requeueConnections(plugin, endpointIndex, namespace, name)
+
+	if nse, ok := obj.(*v1.NetworkServiceEndpoint); ok {
+		return requeueServicesSelecting(plugin, namespace, name, endpointLabels(nse))
+	}
+
+	services, err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister().NetworkServices(namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing NetworkServices in '%s': %s", namespace, err)
+	}
+	for _, ns := range services {
+		plugin.nsController.Enqueue(ns)
+		if err = requeueImporters(plugin, ns.Namespace, ns.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkserviceconnectionDeleted is the delete handler for
+// NetworkServiceConnections. The addresses of the connection are released and
+// its teardown is recorded on its service.
+func networkserviceconnectionDeleted(plugin *Plugin, namespace, name string, obj interface{}) error {
+	plugin.Log.Infof("NetworkServiceConnection '%s/%s' has been deleted. Cleaning up...", namespace, name)
+	if err := runDeleteHooks(plugin, v1.NSMConnectionPlural, namespace, name, obj); err != nil {
+		return err
+	}
+
+	releaseAddresses(plugin, namespace, name)
+
+	if conn, ok := obj.(*v1.NetworkServiceConnection); ok && plugin.IsLeader() {
+		recordConnectionEvent(plugin, conn, EventReasonConnectionTornDown, "Pod %s disconnected from endpoint %s")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// queuedKeys drains the keys queued on a controller which is not running.
+func queuedKeys(c *Controller) []string {
+	var keys []string
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		keys = append(keys, key.(string))
+		c.queue.Done(key)
+		c.queue.Forget(key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestEndpointDeletedRequeuesImporters(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	plugin.nsController = newNetworkServiceController(plugin)
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer()
+	for _, ns := range []*v1.NetworkService{
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-network"},
+			Spec:       netmesh.NetworkService{Selector: "routing"},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "team-a", Name: "gold-import"},
+			Spec: netmesh.NetworkService{
+				Selector: "none",
+				Imports:  []*netmesh.ServiceReference{{Namespace: "default", Name: "gold-network"}},
+			},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "team-a", Name: "local"},
+			Spec:       netmesh.NetworkService{Selector: "routing"},
+		},
+	} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		last interface{}
+	}{
+		{"last state known", &v1.NetworkServiceEndpoint{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-endpoint", Labels: map[string]string{"routing": "true"}},
+		}},
+		{"last state unknown", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := networkserviceendpointDeleted(plugin, "default", "gold-endpoint", test.last); err != nil {
+				t.Fatal(err)
+			}
+			expected := []string{"default/gold-network", "team-a/gold-import"}
+			if keys := queuedKeys(plugin.nsController); !reflect.DeepEqual(keys, expected) {
+				t.Errorf("expected %v to be requeued, got %v", expected, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/scheme"
+)
+
+// This file contains the Kubernetes events recorded on the NSM objects, so
+// that `kubectl describe` shows their operational history. Events are
+// recorded by the leader only, along with the status updates, so that every
+// transition is reported once.
+
+// eventComponent is the source component of the events of the plugin
+const eventComponent = "netmesh-crd"
+
+// Reasons of the events recorded by the plugin. Changes of the state of an
+// object are recorded with the new state as the reason, e.g.
+// v1.NetworkServiceStateMissingChannel.
+const (
+	// EventReasonEndpointsChanged is recorded on a NetworkService when the
+	// endpoints it selects changed
+	EventReasonEndpointsChanged = "EndpointsChanged"
+	// EventReasonUUIDRestored is recorded on an object whose UUID was
+	// modified and has been restored
+	EventReasonUUIDRestored = "UUIDRestored"
+	// EventReasonCleanupFailed is recorded on an object being deleted when
+	// its connections could not be cleaned up
+	EventReasonCleanupFailed = "CleanupFailed"
+	// EventReasonConnectionsTornDown is recorded on an object being deleted
+	// once its connections have been cleaned up
+	EventReasonConnectionsTornDown = "ConnectionsTornDown"
+	// EventReasonOrphaned is recorded on an endpoint whose pod or node no
+	// longer exists, when the orphan sweeper deletes it
+	EventReasonOrphaned = "Orphaned"
+	// EventReasonConnectionEstablished is recorded when a connection through
+	// a NetworkService has been established
+	EventReasonConnectionEstablished = "ConnectionEstablished"
+	// EventReasonConnectionTornDown is recorded when a connection through a
+	// NetworkService has been torn down
+	EventReasonConnectionTornDown = "ConnectionTornDown"
+)
+
+// newEventRecorder creates the recorder of the events on the NSM objects. The
+// objects are referenced through the scheme of the NSM clientset.
+func newEventRecorder(plugin *Plugin) record.EventRecorder {
+	source := corev1.EventSource{Component: eventComponent}
+	if identity, err := leaderIdentity(); err == nil {
+		source.Host = identity
+	}
+	return plugin.eventBroadcaster.NewRecorder(scheme.Scheme, source)
+}
+
+// normalStates lists the states whose transitions are recorded as Normal
+// events, transitions to any other state are recorded as Warning events.
+var normalStates = map[string]bool{
+	v1.NetworkServiceStateReady:                 true,
+	v1.NetworkServiceEndpointStateHealthy:       true,
+	v1.NetworkServiceChannelStateInUse:          true,
+	v1.NetworkServiceChannelStateUnused:         true,
+	v1.NetworkServiceConnectionStateEstablished: true,
+}
+
+// recordStateChange records an event on obj if its state changed from
+// oldState to newState.
+func recordStateChange(plugin *Plugin, obj runtime.Object, oldState, newState, message string) {
+	if oldState == newState || newState == "" {
+		return
+	}
+	eventType := corev1.EventTypeWarning
+	if normalStates[newState] {
+		eventType = corev1.EventTypeNormal
+	}
+	plugin.recorder.Event(obj, eventType, newState, message)
+}
+
+// recordEndpointsChanged records an event on a NetworkService whose selected
+// endpoints changed from oldEndpoints to newEndpoints.
+func recordEndpointsChanged(plugin *Plugin, ns *v1.NetworkService, oldEndpoints, newEndpoints []string) {
+	added := difference(newEndpoints, oldEndpoints)
+	removed := difference(oldEndpoints, newEndpoints)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, fmt.Sprintf("added %s", strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, fmt.Sprintf("removed %s", strings.Join(removed, ", ")))
+	}
+	plugin.recorder.Eventf(ns, corev1.EventTypeNormal, EventReasonEndpointsChanged,
+		"Selected endpoints changed: %s", strings.Join(changes, "; "))
+}
+
+// difference returns the elements of a which are not in b.
+func difference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	var result []string
+	for _, s := range a {
+		if !inB[s] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
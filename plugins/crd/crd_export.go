@@ -0,0 +1,250 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/selector"
+)
+
+// This file contains the isolation of the namespaces. A NetworkService only
+// selects the endpoints of its own namespace, unless it imports services of
+// other namespaces. An imported service has to be exported to the namespace
+// of the importing service, by name or by the labels of the namespace, the
+// importing service then selects the endpoints selected by the imported one.
+
+// newNamespaceInformer creates the informer of the Namespaces, whose labels
+// are matched against the export policies.
+func newNamespaceInformer(plugin *Plugin) cache.SharedIndexInformer {
+	namespaces := plugin.k8sClientset.CoreV1().Namespaces()
+	lw := &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			return namespaces.List(options)
+		},
+		WatchFunc: func(options meta.ListOptions) (watch.Interface, error) {
+			return namespaces.Watch(options)
+		},
+	}
+	return cache.NewSharedIndexInformer(lw, &corev1.Namespace{}, plugin.config.ResyncPeriod.Duration, cache.Indexers{})
+}
+
+// namespaceLabels returns the labels of a namespace, nil if it is not known.
+func namespaceLabels(plugin *Plugin, namespace string) map[string]string {
+	obj, exists, err := plugin.namespaceInformer.GetStore().GetByKey(namespace)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*corev1.Namespace).Labels
+}
+
+// resolveImports returns the services imported by a NetworkService. When an
+// import cannot be resolved, the state and message explaining why are
+// returned instead.
+func resolveImports(plugin *Plugin, ns *v1.NetworkService) (imported []*v1.NetworkService, state, message string, err error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister()
+
+	var missing, denied []string
+	for _, ref := range ns.Spec.Imports {
+		if ref == nil {
+			continue
+		}
+		key := objectKey(ref.Namespace, ref.Name)
+		if !plugin.watchesNamespace(ref.Namespace) {
+			missing = append(missing, key)
+			continue
+		}
+		exporter, err := lister.NetworkServices(ref.Namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, key)
+			continue
+		} else if err != nil {
+			return nil, "", "", err
+		}
+		exported, err := selector.ExportedTo(&exporter.Spec, exporter.Namespace, ns.Namespace, namespaceLabels(plugin, ns.Namespace))
+		if err != nil || !exported {
+			denied = append(denied, key)
+			continue
+		}
+		imported = append(imported, exporter)
+	}
+
+	if len(missing) > 0 {
+		return nil, v1.NetworkServiceStateMissingImport,
+			fmt.Sprintf("imported services not found: %s", strings.Join(missing, ", ")), nil
+	}
+	if len(denied) > 0 {
+		return nil, v1.NetworkServiceStateImportDenied,
+			fmt.Sprintf("imported services not exported to %s: %s", ns.Namespace, strings.Join(denied, ", ")), nil
+	}
+	return imported, "", "", nil
+}
+
+// selectedEndpoints returns the endpoints selected by a NetworkService in its
+// own namespace and through the services it imports. Endpoints being deleted
+// or unhealthy are left out, as they do not accept new connections.
+func selectedEndpoints(plugin *Plugin, ns *v1.NetworkService, imported []*v1.NetworkService) ([]*v1.NetworkServiceEndpoint, error) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Lister()
+
+	var endpoints []*v1.NetworkServiceEndpoint
+	for _, service := range append([]*v1.NetworkService{ns}, imported...) {
+		sel, err := selector.ForService(&service.Spec)
+		if err != nil {
+			if service == ns {
+				return nil, err
+			}
+			// The imported service reports its invalid selector itself
+			continue
+		}
+		// The selector is matched against the labels of the spec as well,
+		// which the lister does not know about
+		candidates, err := lister.NetworkServiceEndpoints(service.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range candidates {
+			if sel.Matches(labels.Set(endpointLabels(endpoint))) && endpointSelectable(endpoint) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// endpointNames returns the sorted names of endpoints as reported in the
+// status of a NetworkService. Endpoints of other namespaces are qualified
+// with their namespace.
+func endpointNames(ns *v1.NetworkService, endpoints []*v1.NetworkServiceEndpoint) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		name := endpoint.Name
+		if endpoint.Namespace != ns.Namespace {
+			name = objectKey(endpoint.Namespace, endpoint.Name)
+		}
+		// An endpoint can be selected through several imports
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	// Listers return objects in no particular order, sort them so that the
+	// status does not change between two reconciles of the same state.
+	sort.Strings(names)
+	return names
+}
+
+// indexByImport indexes a NetworkService by the services it imports.
+func indexByImport(obj interface{}) ([]string, error) {
+	ns, ok := obj.(*v1.NetworkService)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkService cache", obj)
+	}
+	var keys []string
+	for _, ref := range ns.Spec.Imports {
+		if ref != nil {
+			keys = append(keys, objectKey(ref.Namespace, ref.Name))
+		}
+	}
+	return keys, nil
+}
+
+// requeueImporters requeues the services importing a service.
+func requeueImporters(plugin *Plugin, namespace, name string) error {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(importIndex, objectKey(namespace, name))
+	if err != nil {
+		return fmt.Errorf("error looking up NetworkServices importing '%s/%s': %s", namespace, name, err)
+	}
+	for _, obj := range objs {
+		plugin.nsController.Enqueue(obj)
+	}
+	return nil
+}
+
+// requeueImportingServicesIn requeues the services of a namespace which import
+// services, e.g. when the labels of the namespace changed.
+func requeueImportingServicesIn(plugin *Plugin, namespace string) error {
+	services, err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Lister().NetworkServices(namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing NetworkServices of namespace %s: %s", namespace, err)
+	}
+	for _, ns := range services {
+		if len(ns.Spec.Imports) > 0 {
+			plugin.nsController.Enqueue(ns)
+		}
+	}
+	return nil
+}
+
+// addExportHandlers registers event handlers requeuing the importers of a
+// service when it changes, and the importing services of a namespace when
+// its labels change.
+func addExportHandlers(plugin *Plugin) {
+	requeue := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			plugin.Log.Error(err.Error())
+			return
+		}
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			plugin.Log.Error(err.Error())
+			return
+		}
+		if err = requeueImporters(plugin, namespace, name); err != nil {
+			plugin.Log.Error(err.Error())
+		}
+	}
+	plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: requeue,
+			UpdateFunc: func(old, cur interface{}) {
+				// Importers depend on the spec of the service only
+				if !reflect.DeepEqual(old.(*v1.NetworkService).Spec, cur.(*v1.NetworkService).Spec) {
+					requeue(cur)
+				}
+			},
+			DeleteFunc: requeue,
+		},
+	)
+
+	plugin.namespaceInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, cur interface{}) {
+				oldNamespace := old.(*corev1.Namespace)
+				curNamespace := cur.(*corev1.Namespace)
+				if reflect.DeepEqual(oldNamespace.Labels, curNamespace.Labels) {
+					return
+				}
+				if err := requeueImportingServicesIn(plugin, curNamespace.Name); err != nil {
+					plugin.Log.Error(err.Error())
+				}
+			},
+		},
+	)
+}
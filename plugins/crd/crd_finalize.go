@@ -0,0 +1,214 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the handling of the NSM finalizer. The leader adds the
+// finalizer to NetworkServices and NetworkServiceEndpoints, so that the API
+// server keeps them around once deleted until the connections through them
+// have been torn down or migrated by the finalize hooks. The finalizer is
+// removed once all the hooks succeeded, which lets the API server complete
+// the deletion.
+
+// FinalizeHook tears down or migrates the connections through a
+// NetworkService or a NetworkServiceEndpoint being deleted. obj is the object,
+// with its deletion timestamp set. Returning an error retries the hook later
+// with an exponential backoff, the deletion is blocked until all the hooks of
+// the resource succeed.
+type FinalizeHook func(namespace, name string, obj interface{}) error
+
+// RegisterFinalizeHook registers a finalize hook for a NetworkService or a
+// NetworkServiceEndpoint, identified by its plural name (v1.NSMPlural or
+// v1.NSMEPPlural). Finalize hooks are run by the leader only.
+func (plugin *Plugin) RegisterFinalizeHook(resource string, hook FinalizeHook) {
+	plugin.deleteLock.Lock()
+	defer plugin.deleteLock.Unlock()
+
+	plugin.finalizeHooks[resource] = append(plugin.finalizeHooks[resource], hook)
+}
+
+// runFinalizeHooks runs all the hooks registered for a resource.
+func runFinalizeHooks(plugin *Plugin, resource, namespace, name string, obj interface{}) error {
+	plugin.deleteLock.Lock()
+	hooks := append([]FinalizeHook(nil), plugin.finalizeHooks[resource]...)
+	plugin.deleteLock.Unlock()
+
+	for _, hook := range hooks {
+		if err := hook(namespace, name, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasFinalizer returns true if the NSM finalizer is in the list.
+func hasFinalizer(finalizers []string) bool {
+	for _, finalizer := range finalizers {
+		if finalizer == v1.NSMFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutFinalizer returns a copy of the list without the NSM finalizer.
+func withoutFinalizer(finalizers []string) []string {
+	var result []string
+	for _, finalizer := range finalizers {
+		if finalizer != v1.NSMFinalizer {
+			result = append(result, finalizer)
+		}
+	}
+	return result
+}
+
+// finalization describes how the finalizer of an object being deleted is
+// handled, see finalize.
+type finalization struct {
+	// kind of the object, in the logs
+	kind string
+	// resource selects the finalize hooks, see RegisterFinalizeHook
+	resource string
+	// state reported while the connections are cleaned up
+	state string
+	// updateStatus reports the progress in the status of the object, it
+	// updates the object only if its status changed
+	updateStatus func(state, message string, condition v1.Condition) error
+	// removeFinalizer updates the object without the NSM finalizer
+	removeFinalizer func() error
+}
+
+// finalize runs the finalize hooks of an object being deleted and removes the
+// NSM finalizer once they succeeded. The progress is reported in the status.
+// obj is the object from the informer cache and objMeta its metadata.
+func finalize(plugin *Plugin, obj runtime.Object, objMeta *meta.ObjectMeta, f finalization) error {
+	if !hasFinalizer(objMeta.Finalizers) {
+		return nil
+	}
+	namespace, name := objMeta.Namespace, objMeta.Name
+	plugin.Log.Infof("%s '%s/%s' is being deleted. Cleaning up connections...", f.kind, namespace, name)
+
+	hookErr := runFinalizeHooks(plugin, f.resource, namespace, name, obj)
+
+	message := "cleaning up connections"
+	if hookErr != nil {
+		message = fmt.Sprintf("error cleaning up connections: %s", hookErr)
+	}
+	if err := f.updateStatus(f.state, message, readyCondition(objMeta.Generation, f.state, message)); err != nil {
+		return fmt.Errorf("error updating status of '%s/%s': %s", namespace, name, err)
+	}
+	if hookErr != nil {
+		plugin.recorder.Eventf(obj, corev1.EventTypeWarning, EventReasonCleanupFailed, "Error cleaning up connections: %s", hookErr)
+		return fmt.Errorf("error cleaning up connections of '%s/%s': %s", namespace, name, hookErr)
+	}
+
+	if err := f.removeFinalizer(); err != nil {
+		return fmt.Errorf("error removing finalizer from '%s/%s': %s", namespace, name, err)
+	}
+	plugin.Log.Infof("Finished cleaning up connections of %s '%s/%s'", f.kind, namespace, name)
+	plugin.recorder.Event(obj, corev1.EventTypeNormal, EventReasonConnectionsTornDown, "Finished cleaning up connections")
+
+	return nil
+}
+
+// finalizeNetworkService finalizes a NetworkService being deleted, see
+// finalize.
+func finalizeNetworkService(plugin *Plugin, ns *v1.NetworkService) error {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServices(ns.Namespace)
+	nsCopy := ns.DeepCopy()
+	return finalize(plugin, ns, &ns.ObjectMeta, finalization{
+		kind:     "NetworkService",
+		resource: v1.NSMPlural,
+		state:    v1.NetworkServiceStateTerminating,
+		updateStatus: func(state, message string, condition v1.Condition) error {
+			nsCopy.Status.State = state
+			nsCopy.Status.Message = message
+			nsCopy.Status.Conditions = v1.SetCondition(ns.Status.Conditions, condition)
+			if reflect.DeepEqual(nsCopy.Status, ns.Status) {
+				return nil
+			}
+			updated, err := client.UpdateStatus(nsCopy)
+			if err == nil {
+				nsCopy = updated
+			}
+			return err
+		},
+		removeFinalizer: func() error {
+			nsCopy.Finalizers = withoutFinalizer(nsCopy.Finalizers)
+			_, err := client.Update(nsCopy)
+			return err
+		},
+	})
+}
+
+// reconcileNetworkServiceEndpoint makes sure a NetworkServiceEndpoint carries
+// the NSM finalizer and its UUID, records its health, see crd_health.go, and
+// finalizes it once it is being deleted. The object passed in comes from the
+// informer cache and must not be modified.
+func reconcileNetworkServiceEndpoint(plugin *Plugin, nse *v1.NetworkServiceEndpoint) error {
+	if !plugin.IsLeader() {
+		plugin.Log.Debugf("Not the leader, skipping NetworkServiceEndpoint '%s/%s'", nse.Namespace, nse.Name)
+		return nil
+	}
+	if nse.DeletionTimestamp != nil {
+		return finalizeNetworkServiceEndpoint(plugin, nse)
+	}
+	if updated, err := initializeNetworkServiceEndpoint(plugin, nse); err != nil || updated {
+		return err
+	}
+
+	return updateEndpointHealth(plugin, nse)
+}
+
+// finalizeNetworkServiceEndpoint finalizes a NetworkServiceEndpoint being
+// deleted, see finalize.
+func finalizeNetworkServiceEndpoint(plugin *Plugin, nse *v1.NetworkServiceEndpoint) error {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(nse.Namespace)
+	nseCopy := nse.DeepCopy()
+	return finalize(plugin, nse, &nse.ObjectMeta, finalization{
+		kind:     "NetworkServiceEndpoint",
+		resource: v1.NSMEPPlural,
+		state:    v1.NetworkServiceEndpointStateTerminating,
+		updateStatus: func(state, message string, condition v1.Condition) error {
+			nseCopy.Status.State = state
+			nseCopy.Status.Message = message
+			nseCopy.Status.Conditions = v1.SetCondition(nse.Status.Conditions, condition)
+			if reflect.DeepEqual(nseCopy.Status, nse.Status) {
+				return nil
+			}
+			updated, err := client.UpdateStatus(nseCopy)
+			if err == nil {
+				nseCopy = updated
+			}
+			return err
+		},
+		removeFinalizer: func() error {
+			nseCopy.Finalizers = withoutFinalizer(nseCopy.Finalizers)
+			_, err := client.Update(nseCopy)
+			return err
+		},
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the liveness of NetworkServiceEndpoints. Endpoints renew
+// a heartbeat, recorded in the lastHeartbeatTime of their status. The leader
+// marks an endpoint Unhealthy once its last heartbeat is older than the
+// HeartbeatGracePeriod, and Healthy again when heartbeats resume. Unhealthy
+// endpoints are not selected by any service. Endpoints which never sent a
+// heartbeat, e.g. created by hand, are not checked for liveness.
+
+// HeartbeatGracePeriod returns the time after its last heartbeat an endpoint
+// is marked unhealthy. Endpoints should renew their heartbeat well within it.
+func (plugin *Plugin) HeartbeatGracePeriod() time.Duration {
+	return plugin.config.HeartbeatGracePeriod.Duration
+}
+
+// RenewEndpoint records a heartbeat of the NetworkServiceEndpoint with the
+// given UUID on behalf of the pod owning it.
+func (plugin *Plugin) RenewEndpoint(podNamespace, podName, uuid string) error {
+	cached, err := plugin.GetNetworkServiceEndpointByUUID(uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkPodOwner(plugin, v1.NSMEPPlural, &cached.ObjectMeta, uuid, podNamespace, podName); err != nil {
+		return err
+	}
+	client := plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(cached.Namespace)
+
+	// The status is written by the leader as well, the heartbeat is
+	// recorded on the latest version of the object
+	nse := cached.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		nse.Status.LastHeartbeatTime = meta.Now()
+		_, err := client.UpdateStatus(nse)
+		if err == nil {
+			return nil
+		}
+		// Resending the stale object would only conflict again
+		latest, getErr := client.Get(cached.Name, meta.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		if latest.UID != cached.UID {
+			return fmt.Errorf("NetworkServiceEndpoint '%s/%s' has been replaced", cached.Namespace, cached.Name)
+		}
+		nse = latest
+		return err
+	})
+}
+
+// endpointHealth returns the state and message of an endpoint according to
+// its last heartbeat, and the time after which the endpoint has to be checked
+// again. The state is empty for endpoints which never sent a heartbeat.
+func endpointHealth(plugin *Plugin, nse *v1.NetworkServiceEndpoint, now time.Time) (string, string, time.Duration) {
+	last := nse.Status.LastHeartbeatTime
+	if last.IsZero() {
+		return "", "", 0
+	}
+	grace := plugin.config.HeartbeatGracePeriod.Duration
+	expiry := last.Add(grace)
+	if !now.Before(expiry) {
+		return v1.NetworkServiceEndpointStateUnhealthy,
+			fmt.Sprintf("no heartbeat since %s", last.UTC().Format(time.RFC3339)), 0
+	}
+	return v1.NetworkServiceEndpointStateHealthy,
+		fmt.Sprintf("heartbeat renewed within %s", grace), expiry.Sub(now)
+}
+
+// endpointSelectable returns true if an endpoint can be selected by services,
+// i.e. it is neither being deleted nor unhealthy.
+func endpointSelectable(nse *v1.NetworkServiceEndpoint) bool {
+	return nse.DeletionTimestamp == nil && nse.Status.State != v1.NetworkServiceEndpointStateUnhealthy
+}
+
+// updateEndpointHealth records the health of an endpoint in its status. The
+// endpoint is reconciled again when its heartbeat expires.
+func updateEndpointHealth(plugin *Plugin, nse *v1.NetworkServiceEndpoint) error {
+	state, message, recheck := endpointHealth(plugin, nse, time.Now())
+	if recheck > 0 {
+		plugin.nseController.EnqueueKeyAfter(nse.Namespace, nse.Name, recheck)
+	}
+	if state == "" || (state == nse.Status.State && message == nse.Status.Message) {
+		plugin.Log.Debugf("NetworkServiceEndpoint '%s/%s' is up to date", nse.Namespace, nse.Name)
+		return nil
+	}
+
+	nseCopy := nse.DeepCopy()
+	nseCopy.Status.State = state
+	nseCopy.Status.Message = message
+	nseCopy.Status.Conditions = v1.SetCondition(nse.Status.Conditions, readyCondition(nse.Generation, state, message))
+	if _, err := plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(nse.Namespace).UpdateStatus(nseCopy); err != nil {
+		return fmt.Errorf("error updating status of '%s/%s': %s", nse.Namespace, nse.Name, err)
+	}
+	plugin.Log.Infof("NetworkServiceEndpoint '%s/%s' is %s: %s", nse.Namespace, nse.Name, state, message)
+	recordStateChange(plugin, nse, nse.Status.State, state, message)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/fake"
+)
+
+// newRenewTestPlugin returns a plugin with an endpoint of the gold-pod pod in
+// its cache, on a fake clientset failing the status updates of endpoints with
+// a conflict and their gets with getErr. updates counts the status updates.
+func newRenewTestPlugin(t *testing.T, getErr error) (plugin *Plugin, nse *v1.NetworkServiceEndpoint, updates *int) {
+	plugin = newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	pod, err := plugin.k8sClientset.CoreV1().Pods("default").Create(&corev1.Pod{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-pod", UID: "pod-uid"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nse = &v1.NetworkServiceEndpoint{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace:       "default",
+			Name:            "gold-endpoint",
+			UID:             "nse-uid",
+			OwnerReferences: []meta.OwnerReference{podOwnerReference(pod)},
+		},
+		Spec: netmesh.NetworkServiceEndpoint{Uuid: "3c9b2d7e-8f41-4e6a-b5d2-7a0c1e9f4b36"},
+	}
+	if err := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer().Add(nse); err != nil {
+		t.Fatal(err)
+	}
+
+	updates = new(int)
+	client := plugin.crdClient.(*fake.Clientset)
+	client.PrependReactor("update", v1.NSMEPPlural, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		*updates++
+		return true, nil, apierrors.NewConflict(v1.Resource(v1.NSMEPPlural), nse.Name, fmt.Errorf("stale object"))
+	})
+	client.PrependReactor("get", v1.NSMEPPlural, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, getErr
+	})
+	return plugin, nse, updates
+}
+
+func TestRenewEndpointReturnsGetError(t *testing.T) {
+	getErr := fmt.Errorf("connection refused")
+	plugin, nse, updates := newRenewTestPlugin(t, getErr)
+
+	if err := plugin.RenewEndpoint("default", "gold-pod", nse.Spec.Uuid); err != getErr {
+		t.Errorf("expected the error of the get, got %v", err)
+	}
+	if *updates != 1 {
+		t.Errorf("expected the stale object to be sent once, got %d status updates", *updates)
+	}
+}
+
+func TestRenewEndpointReplaced(t *testing.T) {
+	plugin, nse, _ := newRenewTestPlugin(t, nil)
+	replaced := nse.DeepCopy()
+	replaced.UID = "other-uid"
+	client := plugin.crdClient.(*fake.Clientset)
+	client.PrependReactor("get", v1.NSMEPPlural, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, replaced, nil
+	})
+
+	if err := plugin.RenewEndpoint("default", "gold-pod", nse.Spec.Uuid); err == nil || apierrors.IsConflict(err) {
+		t.Errorf("expected the endpoint to be reported as replaced, got %v", err)
+	}
+}
+
+func TestRenewEndpointNotOwned(t *testing.T) {
+	plugin, nse, updates := newRenewTestPlugin(t, nil)
+
+	if err := plugin.RenewEndpoint("default", "silver-pod", nse.Spec.Uuid); !apierrors.IsForbidden(err) {
+		t.Errorf("expected the heartbeat of another pod to be forbidden, got %v", err)
+	}
+	if *updates != 0 {
+		t.Errorf("expected no status update, got %d", *updates)
+	}
+}
@@ -0,0 +1,505 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/selector"
+)
+
+// This file contains the indexes of the informer caches. They are used to
+// requeue the services depending on a channel or an endpoint, and the
+// connections through a service or an endpoint, when the latter changes, and
+// to answer the queries of other plugins, see crd_api.go.
+
+// Names of the indexes of the informers
+const (
+	// channelIndex maps 'namespace/channel' to the services using the
+	// channel
+	channelIndex = "channel"
+	// selectorIndex maps 'namespace/key' to the services whose selector
+	// requires the label key to be present. Selectors which can match
+	// endpoints without any label are indexed under anyLabelKey.
+	selectorIndex = "selector"
+	// uuidIndex maps UUIDs to the services, endpoints and connections
+	uuidIndex = "uuid"
+	// nodeIndex maps node names to the endpoints running on the node
+	nodeIndex = "node"
+	// importIndex maps 'namespace/service' to the services importing the
+	// service, see crd_export.go
+	importIndex = "import"
+	// serviceIndex maps 'namespace/service' to the connections through the
+	// service
+	serviceIndex = "service"
+	// endpointIndex maps 'namespace/endpoint' to the connections to the
+	// endpoint, which may be in another namespace than the connections
+	endpointIndex = "endpoint"
+)
+
+// anyLabelKey is the selectorIndex key of selectors which can match endpoints
+// without any label, e.g. empty selectors or selectors with only negative
+// requirements
+const anyLabelKey = "*"
+
+// addIndexers adds the indexers to the informers, which must not have been
+// started yet.
+func addIndexers(plugin *Plugin) error {
+	err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().AddIndexers(cache.Indexers{
+		channelIndex:  indexByChannel,
+		selectorIndex: indexBySelector,
+		uuidIndex:     indexServiceByUUID,
+		importIndex:   indexByImport,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding NetworkService indexers: %s", err)
+	}
+
+	err = plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().AddIndexers(cache.Indexers{
+		uuidIndex: indexEndpointByUUID,
+		nodeIndex: indexEndpointByNode,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding NetworkServiceEndpoint indexers: %s", err)
+	}
+
+	err = plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().AddIndexers(cache.Indexers{
+		uuidIndex:     indexConnectionByUUID,
+		serviceIndex:  indexConnectionByService,
+		endpointIndex: indexConnectionByEndpoint,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding NetworkServiceConnection indexers: %s", err)
+	}
+
+	return nil
+}
+
+// indexByChannel indexes a NetworkService by the channels it uses.
+func indexByChannel(obj interface{}) ([]string, error) {
+	ns, ok := obj.(*v1.NetworkService)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkService cache", obj)
+	}
+	var keys []string
+	for _, channel := range ns.Spec.Channels {
+		if channel != nil {
+			keys = append(keys, objectKey(ns.Namespace, channel.Name))
+		}
+	}
+	return keys, nil
+}
+
+// indexBySelector indexes a NetworkService by the label keys its selector
+// requires. An endpoint can only match a selector with a positive requirement
+// if it has the label key of the requirement, so it is enough to look up the
+// keys of its labels and anyLabelKey to find all the services selecting it.
+func indexBySelector(obj interface{}) ([]string, error) {
+	ns, ok := obj.(*v1.NetworkService)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkService cache", obj)
+	}
+	sel, err := selector.ForService(&ns.Spec)
+	if err != nil {
+		// An invalid selector does not select any endpoint
+		return nil, nil
+	}
+
+	required, all := selector.RequiredKeys(sel)
+	if all {
+		return []string{objectKey(ns.Namespace, anyLabelKey)}, nil
+	}
+	keys := make([]string, 0, len(required))
+	for _, key := range required {
+		keys = append(keys, objectKey(ns.Namespace, key))
+	}
+	return keys, nil
+}
+
+// indexServiceByUUID indexes a NetworkService by its UUID, see assignedUUID.
+func indexServiceByUUID(obj interface{}) ([]string, error) {
+	ns, ok := obj.(*v1.NetworkService)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkService cache", obj)
+	}
+	id := assignedUUID(ns.Spec.Uuid, ns.Status.UUID)
+	if id == "" {
+		return nil, nil
+	}
+	return []string{id}, nil
+}
+
+// indexEndpointByUUID indexes a NetworkServiceEndpoint by its UUID, see
+// assignedUUID.
+func indexEndpointByUUID(obj interface{}) ([]string, error) {
+	nse, ok := obj.(*v1.NetworkServiceEndpoint)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkServiceEndpoint cache", obj)
+	}
+	id := assignedUUID(nse.Spec.Uuid, nse.Status.UUID)
+	if id == "" {
+		return nil, nil
+	}
+	return []string{id}, nil
+}
+
+// indexEndpointByNode indexes a NetworkServiceEndpoint by the node it runs
+// on, see endpointNode.
+func indexEndpointByNode(obj interface{}) ([]string, error) {
+	nse, ok := obj.(*v1.NetworkServiceEndpoint)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkServiceEndpoint cache", obj)
+	}
+	node := endpointNode(nse)
+	if node == "" {
+		return nil, nil
+	}
+	return []string{node}, nil
+}
+
+// indexConnectionByUUID indexes a NetworkServiceConnection by its UUID, see
+// assignedUUID.
+func indexConnectionByUUID(obj interface{}) ([]string, error) {
+	conn, ok := obj.(*v1.NetworkServiceConnection)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkServiceConnection cache", obj)
+	}
+	id := assignedUUID(conn.Spec.Uuid, conn.Status.UUID)
+	if id == "" {
+		return nil, nil
+	}
+	return []string{id}, nil
+}
+
+// indexConnectionByService indexes a NetworkServiceConnection by its service.
+func indexConnectionByService(obj interface{}) ([]string, error) {
+	conn, ok := obj.(*v1.NetworkServiceConnection)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkServiceConnection cache", obj)
+	}
+	if conn.Spec.NetworkService == "" {
+		return nil, nil
+	}
+	return []string{objectKey(conn.Namespace, conn.Spec.NetworkService)}, nil
+}
+
+// indexConnectionByEndpoint indexes a NetworkServiceConnection by its
+// endpoint.
+func indexConnectionByEndpoint(obj interface{}) ([]string, error) {
+	conn, ok := obj.(*v1.NetworkServiceConnection)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object of type %T in NetworkServiceConnection cache", obj)
+	}
+	if conn.Spec.Endpoint == nil || conn.Spec.Endpoint.Name == "" {
+		return nil, nil
+	}
+	return []string{objectKey(conn.Spec.Endpoint.Namespace, conn.Spec.Endpoint.Name)}, nil
+}
+
+// endpointNode returns the node a NetworkServiceEndpoint runs on, as given by
+// its spec or, for endpoints which predate spec.node, by its v1.NSMNodeLabel
+// label.
+func endpointNode(nse *v1.NetworkServiceEndpoint) string {
+	if nse.Spec.Node != "" {
+		return nse.Spec.Node
+	}
+	return nse.Labels[v1.NSMNodeLabel]
+}
+
+// endpointLabels returns the labels services select a NetworkServiceEndpoint
+// by: the labels advertised in its spec along with the labels of its object,
+// which win when both set a key.
+func endpointLabels(nse *v1.NetworkServiceEndpoint) map[string]string {
+	if len(nse.Spec.Labels) == 0 {
+		return nse.Labels
+	}
+	merged := make(map[string]string, len(nse.Spec.Labels)+len(nse.Labels))
+	for key, value := range nse.Spec.Labels {
+		merged[key] = value
+	}
+	for key, value := range nse.Labels {
+		merged[key] = value
+	}
+	return merged
+}
+
+// servicesUsingChannel returns the services using a channel.
+func servicesUsingChannel(plugin *Plugin, namespace, name string) ([]*v1.NetworkService, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(channelIndex, objectKey(namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*v1.NetworkService, 0, len(objs))
+	for _, obj := range objs {
+		services = append(services, obj.(*v1.NetworkService))
+	}
+	return services, nil
+}
+
+// servicesSelecting returns the services whose selector matches an endpoint
+// with the given labels.
+func servicesSelecting(plugin *Plugin, namespace string, endpointLabels map[string]string) ([]*v1.NetworkService, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer()
+
+	keys := []string{objectKey(namespace, anyLabelKey)}
+	for key := range endpointLabels {
+		keys = append(keys, objectKey(namespace, key))
+	}
+
+	seen := make(map[string]bool)
+	var services []*v1.NetworkService
+	for _, key := range keys {
+		objs, err := indexer.ByIndex(selectorIndex, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			ns := obj.(*v1.NetworkService)
+			if seen[ns.Name] {
+				continue
+			}
+			seen[ns.Name] = true
+			if selector.Matches(&ns.Spec, endpointLabels) {
+				services = append(services, ns)
+			}
+		}
+	}
+	return services, nil
+}
+
+// requeueServicesUsingChannel requeues the services using a channel.
+func requeueServicesUsingChannel(plugin *Plugin, namespace, name string) error {
+	services, err := servicesUsingChannel(plugin, namespace, name)
+	if err != nil {
+		return fmt.Errorf("error looking up NetworkServices using '%s/%s': %s", namespace, name, err)
+	}
+	for _, ns := range services {
+		plugin.nsController.Enqueue(ns)
+	}
+	return nil
+}
+
+// requeueConnectionsUsingChannel requeues the connections through the
+// services using a channel.
+func requeueConnectionsUsingChannel(plugin *Plugin, namespace, name string) {
+	services, err := servicesUsingChannel(plugin, namespace, name)
+	if err != nil {
+		plugin.Log.Errorf("Error looking up NetworkServices using '%s/%s': %s", namespace, name, err)
+		return
+	}
+	for _, ns := range services {
+		requeueConnections(plugin, serviceIndex, ns.Namespace, ns.Name)
+	}
+}
+
+// requeueServicesSelecting requeues the services selecting an endpoint with
+// any of the given label sets, e.g. its labels before and after an update,
+// along with the services importing them.
+func requeueServicesSelecting(plugin *Plugin, namespace, name string, labelSets ...map[string]string) error {
+	for _, endpointLabels := range labelSets {
+		services, err := servicesSelecting(plugin, namespace, endpointLabels)
+		if err != nil {
+			return fmt.Errorf("error looking up NetworkServices selecting '%s/%s': %s", namespace, name, err)
+		}
+		for _, ns := range services {
+			plugin.nsController.Enqueue(ns)
+			if err = requeueImporters(plugin, ns.Namespace, ns.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requeueChannelsOf requeues the existing channels used by any of the given
+// services, e.g. a service before and after an update. Channels which do not
+// exist are not requeued, as the controller would handle them as deleted.
+func requeueChannelsOf(plugin *Plugin, services ...*v1.NetworkService) {
+	lister := plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Lister()
+	for _, ns := range services {
+		for _, channel := range ns.Spec.Channels {
+			if channel == nil {
+				continue
+			}
+			if nsc, err := lister.NetworkServiceChannels(ns.Namespace).Get(channel.Name); err == nil {
+				plugin.nscController.Enqueue(nsc)
+			}
+		}
+	}
+}
+
+// connectionsByIndex returns the connections under a key of one of the
+// indexes of the connection informer.
+func connectionsByIndex(plugin *Plugin, index, key string) ([]*v1.NetworkServiceConnection, error) {
+	indexer := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().GetIndexer()
+	objs, err := indexer.ByIndex(index, key)
+	if err != nil {
+		return nil, err
+	}
+	connections := make([]*v1.NetworkServiceConnection, 0, len(objs))
+	for _, obj := range objs {
+		connections = append(connections, obj.(*v1.NetworkServiceConnection))
+	}
+	return connections, nil
+}
+
+// requeueConnections requeues the connections under a key of one of the
+// indexes of the connection informer, e.g. the connections through a service.
+func requeueConnections(plugin *Plugin, index, namespace, name string) {
+	connections, err := connectionsByIndex(plugin, index, objectKey(namespace, name))
+	if err != nil {
+		plugin.Log.Errorf("Error looking up NetworkServiceConnections by %s '%s/%s': %s", index, namespace, name, err)
+		return
+	}
+	for _, conn := range connections {
+		plugin.connController.Enqueue(conn)
+	}
+}
+
+// addDependencyHandlers registers event handlers on the channel and endpoint
+// informers, which requeue the services depending on the channels and
+// endpoints added or updated, and on the service informer, which requeues the
+// channels used by the services. The connections through the services and
+// endpoints updated are requeued as well, and so are the connections to an
+// endpoint with a capacity when one of them is added or deleted. Deletions of
+// channels and endpoints are handled by the delete handlers, see
+// crd_delete.go.
+func addDependencyHandlers(plugin *Plugin) {
+	plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				requeueChannelsOf(plugin, obj.(*v1.NetworkService))
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldNS := old.(*v1.NetworkService)
+				curNS := cur.(*v1.NetworkService)
+				// Connections depend on the endpoints and the
+				// channels of the service, as well as on its deletion
+				if oldNS.ResourceVersion != curNS.ResourceVersion {
+					requeueConnections(plugin, serviceIndex, curNS.Namespace, curNS.Name)
+				}
+				// Services may overlap the prefixes of the service
+				if !reflect.DeepEqual(oldNS.Spec.Prefixes, curNS.Spec.Prefixes) {
+					requeueServicesWithPrefixes(plugin)
+				}
+				if reflect.DeepEqual(oldNS.Spec.Channels, curNS.Spec.Channels) {
+					return
+				}
+				requeueChannelsOf(plugin, oldNS, curNS)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if ns, ok := obj.(*v1.NetworkService); ok {
+					requeueChannelsOf(plugin, ns)
+					if len(ns.Spec.Prefixes) > 0 {
+						requeueServicesWithPrefixes(plugin)
+					}
+				}
+			},
+		},
+	)
+
+	plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				nsc := obj.(*v1.NetworkServiceChannel)
+				if err := requeueServicesUsingChannel(plugin, nsc.Namespace, nsc.Name); err != nil {
+					plugin.Log.Error(err.Error())
+				}
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldNSC := old.(*v1.NetworkServiceChannel)
+				curNSC := cur.(*v1.NetworkServiceChannel)
+				// Services check the payload they request against the
+				// channel, and connections depend on it
+				if reflect.DeepEqual(oldNSC.Spec, curNSC.Spec) {
+					return
+				}
+				if err := requeueServicesUsingChannel(plugin, curNSC.Namespace, curNSC.Name); err != nil {
+					plugin.Log.Error(err.Error())
+				}
+				requeueConnectionsUsingChannel(plugin, curNSC.Namespace, curNSC.Name)
+			},
+		},
+	)
+
+	plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				nse := obj.(*v1.NetworkServiceEndpoint)
+				if err := requeueServicesSelecting(plugin, nse.Namespace, nse.Name, endpointLabels(nse)); err != nil {
+					plugin.Log.Error(err.Error())
+				}
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldNSE := old.(*v1.NetworkServiceEndpoint)
+				curNSE := cur.(*v1.NetworkServiceEndpoint)
+				if oldNSE.ResourceVersion != curNSE.ResourceVersion {
+					requeueConnections(plugin, endpointIndex, curNSE.Namespace, curNSE.Name)
+				}
+				// Services depend on the labels of the endpoint and on
+				// whether it is selectable, i.e. neither being deleted
+				// nor unhealthy
+				oldLabels, curLabels := endpointLabels(oldNSE), endpointLabels(curNSE)
+				if reflect.DeepEqual(oldLabels, curLabels) &&
+					endpointSelectable(oldNSE) == endpointSelectable(curNSE) {
+					return
+				}
+				if err := requeueServicesSelecting(plugin, curNSE.Namespace, curNSE.Name, oldLabels, curLabels); err != nil {
+					plugin.Log.Error(err.Error())
+				}
+			},
+		},
+	)
+
+	// Which connections exceed the capacity of their endpoint depends on
+	// all the connections to the endpoint, see connectionOverCapacity
+	plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				requeueConnectionsSharingEndpoint(plugin, obj.(*v1.NetworkServiceConnection))
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if conn, ok := obj.(*v1.NetworkServiceConnection); ok {
+					requeueConnectionsSharingEndpoint(plugin, conn)
+				}
+			},
+		},
+	)
+}
+
+// requeueConnectionsSharingEndpoint requeues the connections to the endpoint
+// of a connection, if the endpoint has a capacity.
+func requeueConnectionsSharingEndpoint(plugin *Plugin, conn *v1.NetworkServiceConnection) {
+	ref := conn.Spec.Endpoint
+	if ref == nil {
+		return
+	}
+	nse, err := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Lister().NetworkServiceEndpoints(ref.Namespace).Get(ref.Name)
+	if err != nil || nse.Spec.Capacity == 0 {
+		return
+	}
+	requeueConnections(plugin, endpointIndex, ref.Namespace, ref.Name)
+}
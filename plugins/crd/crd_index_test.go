@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+func TestEndpointLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		advertised map[string]string
+		expected   map[string]string
+	}{
+		{"no labels", nil, nil, nil},
+		{"object labels", map[string]string{"routing": "true"}, nil, map[string]string{"routing": "true"}},
+		{"advertised labels", nil, map[string]string{"tier": "gold"}, map[string]string{"tier": "gold"}},
+		{
+			name:       "merged labels",
+			labels:     map[string]string{"routing": "true"},
+			advertised: map[string]string{"tier": "gold"},
+			expected:   map[string]string{"routing": "true", "tier": "gold"},
+		},
+		{
+			name:       "object labels win",
+			labels:     map[string]string{"tier": "silver"},
+			advertised: map[string]string{"tier": "gold", "routing": "true"},
+			expected:   map[string]string{"routing": "true", "tier": "silver"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nse := &v1.NetworkServiceEndpoint{
+				ObjectMeta: meta.ObjectMeta{Labels: test.labels},
+				Spec:       netmesh.NetworkServiceEndpoint{Labels: test.advertised},
+			}
+			if labels := endpointLabels(nse); !reflect.DeepEqual(labels, test.expected) {
+				t.Errorf("expected labels %v, got %v", test.expected, labels)
+			}
+		})
+	}
+}
+
+func TestServicesSelectAdvertisedLabels(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	if err := addIndexers(plugin); err != nil {
+		t.Fatal(err)
+	}
+	ns := &v1.NetworkService{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-network"},
+		Spec:       netmesh.NetworkService{Selector: "tier=gold"},
+	}
+	if err := plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().GetIndexer().Add(ns); err != nil {
+		t.Fatal(err)
+	}
+	endpoints := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().GetIndexer()
+	for _, nse := range []*v1.NetworkServiceEndpoint{
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "advertised"},
+			Spec:       netmesh.NetworkServiceEndpoint{Labels: map[string]string{"tier": "gold"}},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "labelled", Labels: map[string]string{"tier": "gold"}},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "overridden", Labels: map[string]string{"tier": "silver"}},
+			Spec:       netmesh.NetworkServiceEndpoint{Labels: map[string]string{"tier": "gold"}},
+		},
+	} {
+		if err := endpoints.Add(nse); err != nil {
+			t.Fatal(err)
+		}
+		services, err := servicesSelecting(plugin, nse.Namespace, endpointLabels(nse))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if selected := len(services) == 1; selected != (nse.Name != "overridden") {
+			t.Errorf("unexpected services selecting %s: %v", nse.Name, services)
+		}
+	}
+
+	selected, err := selectedEndpoints(plugin, ns, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := endpointNames(ns, selected); !reflect.DeepEqual(names, []string{"advertised", "labelled"}) {
+		t.Errorf("expected the endpoints advertised and labelled to be selected, got %v", names)
+	}
+}
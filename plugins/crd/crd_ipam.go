@@ -0,0 +1,243 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/ipam"
+)
+
+// This file contains the allocation of the addresses of connections from the
+// prefixes of their NetworkService, see pkg/nsm/ipam. The leader allocates
+// the addresses when it reconciles a connection and records them in the status
+// of the connection. It keeps a pool per service in memory, rebuilt from the
+// statuses of the connections when it starts leading or when the prefixes of
+// the service change, so that addresses survive restarts. The addresses of a
+// connection are released when the connection is deleted.
+
+// addressPools holds the pools of addresses of the services, by namespace and
+// name of the service
+type addressPools struct {
+	sync.Mutex
+	pools map[string]*servicePool
+}
+
+// servicePool is the pool of addresses of a service, along with the UID and
+// the prefixes of the service it has been built for
+type servicePool struct {
+	uid      types.UID
+	prefixes []string
+	pool     *ipam.Pool
+}
+
+// connectionAddresses returns the addresses of a connection through a service,
+// allocating them if the connection has none yet. The addresses recorded in
+// the status of the connection are kept as long as they are in the prefixes of
+// the service. Connections through services without prefixes get no
+// addresses. problem describes why no address can be allocated, err is only
+// returned when the caches cannot be read.
+func connectionAddresses(plugin *Plugin, ns *v1.NetworkService, conn *v1.NetworkServiceConnection) (addresses *v1.ConnectionAddresses, problem string, err error) {
+	if len(ns.Spec.Prefixes) == 0 {
+		return nil, "", nil
+	}
+	state, message, err := prefixStatus(plugin, ns)
+	if err != nil {
+		return nil, "", err
+	}
+	if state != "" {
+		return nil, fmt.Sprintf("service %s is %s: %s", ns.Name, state, message), nil
+	}
+
+	plugin.addresses.Lock()
+	defer plugin.addresses.Unlock()
+	pool, err := addressPool(plugin, ns)
+	if err != nil {
+		return nil, "", err
+	}
+	owner := objectKey(conn.Namespace, conn.Name)
+	var allocation *ipam.Allocation
+	if recorded := conn.Status.Addresses; recorded != nil {
+		allocation, err = pool.Reserve(owner, recorded.Subnet)
+		if err != nil {
+			plugin.Log.Warnf("Readdressing NetworkServiceConnection '%s': %s", owner, err)
+		}
+	}
+	if allocation == nil {
+		allocation, err = pool.Allocate(owner)
+		if err == ipam.ErrExhausted {
+			return nil, fmt.Sprintf("service %s: %s %s", ns.Name, err, pool), nil
+		} else if err != nil {
+			return nil, "", err
+		}
+	}
+	return &v1.ConnectionAddresses{
+		Subnet:   allocation.Subnet.String(),
+		Client:   allocation.Client.String(),
+		Endpoint: allocation.Endpoint.String(),
+	}, "", nil
+}
+
+// addressPool returns the pool of addresses of a service, building it when the
+// service has none yet or when its prefixes changed. The allocations of the
+// previous pool of the service still in its prefixes are carried over, and
+// the addresses recorded in the status of the connections through the service
+// are restored, those of the oldest connections first. The caller must hold
+// the lock of plugin.addresses.
+func addressPool(plugin *Plugin, ns *v1.NetworkService) (*ipam.Pool, error) {
+	key := objectKey(ns.Namespace, ns.Name)
+	if plugin.addresses.pools == nil {
+		plugin.addresses.pools = make(map[string]*servicePool)
+	}
+	previous := plugin.addresses.pools[key]
+	if previous != nil && previous.uid == ns.UID && reflect.DeepEqual(previous.prefixes, ns.Spec.Prefixes) {
+		return previous.pool, nil
+	}
+
+	pool, err := ipam.NewPool(ns.Spec.Prefixes)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.uid == ns.UID {
+		for owner, allocation := range previous.pool.Allocations() {
+			pool.Reserve(owner, allocation.Subnet.String())
+		}
+	}
+	connections, err := connectionsByIndex(plugin, serviceIndex, key)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		return olderObject(&connections[i].ObjectMeta, &connections[j].ObjectMeta)
+	})
+	for _, conn := range connections {
+		if conn.Status.Addresses == nil {
+			continue
+		}
+		owner := objectKey(conn.Namespace, conn.Name)
+		if _, err := pool.Reserve(owner, conn.Status.Addresses.Subnet); err != nil {
+			plugin.Log.Debugf("Not restoring the addresses of NetworkServiceConnection '%s': %s", owner, err)
+		}
+	}
+
+	plugin.addresses.pools[key] = &servicePool{
+		uid:      ns.UID,
+		prefixes: append([]string(nil), ns.Spec.Prefixes...),
+		pool:     pool,
+	}
+	plugin.Log.Infof("Built the pool of addresses of NetworkService '%s' from prefixes %s", key, pool)
+	return pool, nil
+}
+
+// releaseAddresses releases the addresses of a deleted connection.
+func releaseAddresses(plugin *Plugin, namespace, name string) {
+	plugin.addresses.Lock()
+	defer plugin.addresses.Unlock()
+	for _, sp := range plugin.addresses.pools {
+		sp.pool.Release(objectKey(namespace, name))
+	}
+}
+
+// dropAddressPool drops the pool of addresses of a deleted service.
+func dropAddressPool(plugin *Plugin, namespace, name string) {
+	plugin.addresses.Lock()
+	defer plugin.addresses.Unlock()
+	delete(plugin.addresses.pools, objectKey(namespace, name))
+}
+
+// resetAddressPools drops the pools of addresses of all services, e.g. when
+// this instance stops leading, so that they are rebuilt from the statuses of
+// the connections.
+func resetAddressPools(plugin *Plugin) {
+	plugin.addresses.Lock()
+	defer plugin.addresses.Unlock()
+	plugin.addresses.pools = nil
+}
+
+// prefixStatus checks the prefixes of a service, which must be valid and must
+// not overlap the prefixes of the services of any watched namespace. Of two
+// overlapping services, the younger one is reported, so that the addresses
+// allocated through the older one stay valid. It returns the state of the
+// service and its message, both empty when the prefixes are usable.
+func prefixStatus(plugin *Plugin, ns *v1.NetworkService) (state, message string, err error) {
+	prefixes, err := ipam.ParsePrefixes(ns.Spec.Prefixes)
+	if err != nil {
+		return v1.NetworkServiceStateInvalidPrefix, err.Error(), nil
+	}
+	if len(prefixes) == 0 {
+		return "", "", nil
+	}
+	services, err := plugin.ListNetworkServices("")
+	if err != nil {
+		return "", "", err
+	}
+
+	var overlaps []string
+	for _, other := range services {
+		otherKey := objectKey(other.Namespace, other.Name)
+		if other.UID == ns.UID || len(other.Spec.Prefixes) == 0 ||
+			!olderObject(&other.ObjectMeta, &ns.ObjectMeta) {
+			continue
+		}
+		otherPrefixes, err := ipam.ParsePrefixes(other.Spec.Prefixes)
+		if err != nil {
+			continue
+		}
+		for _, prefix := range prefixes {
+			for _, otherPrefix := range otherPrefixes {
+				if ipam.Overlap(prefix, otherPrefix) {
+					overlaps = append(overlaps, fmt.Sprintf("%s overlaps %s of service %s", prefix, otherPrefix, otherKey))
+				}
+			}
+		}
+	}
+	if len(overlaps) > 0 {
+		sort.Strings(overlaps)
+		return v1.NetworkServiceStatePrefixOverlap, strings.Join(overlaps, "; "), nil
+	}
+	return "", "", nil
+}
+
+// olderObject returns true if the object a has been created before the object
+// b, ties are broken by namespace and name.
+func olderObject(a, b *meta.ObjectMeta) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return objectKey(a.Namespace, a.Name) < objectKey(b.Namespace, b.Name)
+}
+
+// requeueServicesWithPrefixes requeues the services which have prefixes, e.g.
+// when the prefixes of a service changed, as they may overlap.
+func requeueServicesWithPrefixes(plugin *Plugin) {
+	services, err := plugin.ListNetworkServices("")
+	if err != nil {
+		plugin.Log.Errorf("Error listing NetworkServices: %s", err)
+		return
+	}
+	for _, ns := range services {
+		if len(ns.Spec.Prefixes) > 0 {
+			plugin.nsController.Enqueue(ns)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// This file contains the leader election between the instances of the plugin.
+// netmesh runs as a DaemonSet, every instance watches all NSM objects and runs
+// the node-local work, such as delete hooks. Writes to the API server which
+// concern the whole cluster, such as status updates, are done by the leader
+// only.
+
+// Leader election parameters. A new leader is elected at the latest
+// leaseDuration after the pod of the current leader died.
+const (
+	leaderLockName = "networkservicemesh-crd"
+	leaseDuration  = time.Second * 15
+	renewDeadline  = time.Second * 10
+	retryPeriod    = time.Second * 2
+)
+
+// electionTiming holds the durations of the leader election, the constants
+// above unless changed by tests.
+type electionTiming struct {
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+var defaultElectionTiming = electionTiming{
+	leaseDuration: leaseDuration,
+	renewDeadline: renewDeadline,
+	retryPeriod:   retryPeriod,
+}
+
+// stopTimeout bounds the time the elector takes to return once stopped: it
+// leaves the acquire loop at its next attempt, at most a jittered retry
+// period later, and then fails to renew the lease for renewDeadline.
+func (t electionTiming) stopTimeout() time.Duration {
+	retry := time.Duration(float64(t.retryPeriod) * (1 + leaderelection.JitterFactor))
+	return t.renewDeadline + 2*retry
+}
+
+// Environment variables set from the downward API in the DaemonSet, which
+// identify the pod running the plugin.
+const (
+	podNameEnv      = "POD_NAME"
+	podNamespaceEnv = "POD_NAMESPACE"
+)
+
+// IsLeader returns true if this instance of the plugin currently holds the
+// leader lease, i.e. is in charge of the cluster-wide reconciliation.
+func (plugin *Plugin) IsLeader() bool {
+	return atomic.LoadInt32(&plugin.leader) == 1
+}
+
+// leaderIdentity returns the identity of the instance in the election, the
+// name of its pod or the host name when not run in a pod.
+func leaderIdentity() (string, error) {
+	if name := os.Getenv(podNameEnv); name != "" {
+		return name, nil
+	}
+	return os.Hostname()
+}
+
+// leaderNamespace returns the namespace of the lock of the election.
+func leaderNamespace() string {
+	if namespace := os.Getenv(podNamespaceEnv); namespace != "" {
+		return namespace
+	}
+	return corev1.NamespaceDefault
+}
+
+// newLeaderElector creates the leader elector of the plugin. The lease is
+// held in a ConfigMap in the namespace of the pod, the elector stops taking
+// part in the election once stopCh is closed, see stoppableLock.
+func newLeaderElector(plugin *Plugin, stopCh <-chan struct{}) (*leaderelection.LeaderElector, error) {
+	identity, err := leaderIdentity()
+	if err != nil {
+		return nil, fmt.Errorf("error getting leader election identity: %s", err)
+	}
+
+	// Leadership changes are recorded as events of the lock
+	recorder := plugin.eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "netmesh", Host: identity})
+	lock, err := resourcelock.New(resourcelock.ConfigMapsResourceLock,
+		leaderNamespace(),
+		leaderLockName,
+		plugin.k8sClientset.CoreV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: recorder,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error creating leader election lock: %s", err)
+	}
+
+	timing := plugin.electionTiming
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          &stoppableLock{Interface: lock, stopCh: stopCh},
+		LeaseDuration: timing.leaseDuration,
+		RenewDeadline: timing.renewDeadline,
+		RetryPeriod:   timing.retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(stop <-chan struct{}) {
+				select {
+				case <-stopCh:
+					// Let out of the acquire loop by the stopped lock
+				default:
+					startedLeading(plugin)
+				}
+			},
+			OnStoppedLeading: func() {
+				stoppedLeading(plugin)
+			},
+			OnNewLeader: func(leader string) {
+				plugin.Log.Infof("Leader of %s is %s", lock.Describe(), leader)
+			},
+		},
+	})
+}
+
+// runLeaderElection takes part in the election until stopCh is closed. An
+// instance which lost the lease becomes a candidate again.
+func runLeaderElection(elector *leaderelection.LeaderElector, stopCh <-chan struct{}) {
+	for {
+		elector.Run()
+
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// errElectionStopped is returned by a stopped lock.
+var errElectionStopped = errors.New("leader election stopped")
+
+// stoppableLock wraps the lock of the election so that the client-go leader
+// elector, which cannot be interrupted in the version of client-go we use,
+// returns once stopCh is closed. A stopped lock no longer reaches the API
+// server: the first attempt to acquire or renew the lease after the stop
+// succeeds, which lets a candidate out of its acquire loop, and all the
+// following ones fail, so the elector stops leading after renewDeadline.
+type stoppableLock struct {
+	resourcelock.Interface
+	stopCh <-chan struct{}
+	// Set to 1 once the lease was handed out after the stop
+	released int32
+}
+
+func (l *stoppableLock) stopped() bool {
+	select {
+	case <-l.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get reads the lease, a stopped lock pretends it does not exist once so
+// that the elector creates it.
+func (l *stoppableLock) Get() (*resourcelock.LeaderElectionRecord, error) {
+	if !l.stopped() {
+		return l.Interface.Get()
+	}
+	if atomic.CompareAndSwapInt32(&l.released, 0, 1) {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), leaderLockName)
+	}
+	return nil, errElectionStopped
+}
+
+// Create creates the lease, it always succeeds on a stopped lock as it is
+// only called after Get handed out the lease.
+func (l *stoppableLock) Create(record resourcelock.LeaderElectionRecord) error {
+	if !l.stopped() {
+		return l.Interface.Create(record)
+	}
+	return nil
+}
+
+// Update updates the lease, it always fails on a stopped lock.
+func (l *stoppableLock) Update(record resourcelock.LeaderElectionRecord) error {
+	if !l.stopped() {
+		return l.Interface.Update(record)
+	}
+	return errElectionStopped
+}
+
+// RecordEvent records leadership changes, except those of a stopped lock.
+func (l *stoppableLock) RecordEvent(event string) {
+	if !l.stopped() {
+		l.Interface.RecordEvent(event)
+	}
+}
+
+// startedLeading is called when this instance acquired the lease. All
+// objects are requeued, as the reconciliation of the cluster-wide state has
+// been skipped while another instance was the leader.
+func startedLeading(plugin *Plugin) {
+	atomic.StoreInt32(&plugin.leader, 1)
+	plugin.Log.Info("Acquired leader lease, starting cluster-wide reconciliation")
+
+	plugin.nsController.Resync()
+	plugin.nscController.Resync()
+	plugin.nseController.Resync()
+	plugin.connController.Resync()
+}
+
+// stoppedLeading is called when this instance lost the lease or stops.
+func stoppedLeading(plugin *Plugin) {
+	if !atomic.CompareAndSwapInt32(&plugin.leader, 1, 0) {
+		// The lease was handed out by a stopped lock
+		return
+	}
+	resetAddressPools(plugin)
+	plugin.Log.Warn("Stopped leading, stopping cluster-wide reconciliation")
+}
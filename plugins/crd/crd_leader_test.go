@@ -0,0 +1,127 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// memoryLock is a resource lock held in memory.
+type memoryLock struct {
+	record *resourcelock.LeaderElectionRecord
+	events []string
+}
+
+func (l *memoryLock) Get() (*resourcelock.LeaderElectionRecord, error) {
+	if l.record == nil {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), leaderLockName)
+	}
+	record := *l.record
+	return &record, nil
+}
+
+func (l *memoryLock) Create(record resourcelock.LeaderElectionRecord) error {
+	l.record = &record
+	return nil
+}
+
+func (l *memoryLock) Update(record resourcelock.LeaderElectionRecord) error {
+	l.record = &record
+	return nil
+}
+
+func (l *memoryLock) RecordEvent(event string) { l.events = append(l.events, event) }
+func (l *memoryLock) Identity() string         { return "memory" }
+func (l *memoryLock) Describe() string         { return "memory/" + leaderLockName }
+
+func TestStoppableLock(t *testing.T) {
+	inner := &memoryLock{}
+	stopCh := make(chan struct{})
+	lock := &stoppableLock{Interface: inner, stopCh: stopCh}
+
+	// A running lock reaches the underlying lock
+	if _, err := lock.Get(); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the lock not to exist, got %v", err)
+	}
+	record := resourcelock.LeaderElectionRecord{HolderIdentity: "memory"}
+	if err := lock.Create(record); err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	lock.RecordEvent("became leader")
+	if got, err := lock.Get(); err != nil || got.HolderIdentity != "memory" {
+		t.Fatalf("expected the created record, got %v, %v", got, err)
+	}
+
+	close(stopCh)
+
+	// The lease is handed out once, so that a candidate leaves its acquire
+	// loop, without reaching the underlying lock
+	if _, err := lock.Get(); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the stopped lock to hand out the lease, got %v", err)
+	}
+	if err := lock.Create(resourcelock.LeaderElectionRecord{HolderIdentity: "other"}); err != nil {
+		t.Fatalf("Create of the handed out lease failed: %s", err)
+	}
+
+	// Renewals then fail
+	for i := 0; i < 3; i++ {
+		if _, err := lock.Get(); err != errElectionStopped {
+			t.Fatalf("expected Get to fail once stopped, got %v", err)
+		}
+		if err := lock.Update(record); err != errElectionStopped {
+			t.Fatalf("expected Update to fail once stopped, got %v", err)
+		}
+	}
+	lock.RecordEvent("stopped leading")
+
+	if inner.record.HolderIdentity != "memory" {
+		t.Errorf("stopped lock wrote to the underlying lock: %v", inner.record)
+	}
+	if len(inner.events) != 1 {
+		t.Errorf("stopped lock recorded events: %v", inner.events)
+	}
+}
+
+func TestLeaderHandsOverOnClose(t *testing.T) {
+	defer os.Unsetenv(podNameEnv)
+
+	os.Setenv(podNameEnv, "netmesh-a")
+	first := newTestPlugin(t, time.Second)
+	startTestPlugin(t, first)
+
+	// The second instance shares the API server of the first one
+	os.Setenv(podNameEnv, "netmesh-b")
+	second := newTestPlugin(t, time.Second)
+	second.k8sClientset = first.k8sClientset
+	if err := second.start(); err != nil {
+		t.Fatalf("error starting the second plugin: %s", err)
+	}
+	defer second.Close()
+	eventually(t, "the second plugin to sync", second.HasSynced)
+	if second.IsLeader() {
+		t.Fatal("both instances lead the election")
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	eventually(t, "the second plugin to lead", second.IsLeader)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the publication of NetworkServiceEndpoints on behalf of
+// pods. Such endpoints carry an owner reference to their pod, so that the
+// Kubernetes garbage collector deletes them along with the pod, and the node
+// of the pod in their spec and their v1.NSMNodeLabel label. Endpoints left behind are
+// deleted by the orphan sweeper, see crd_sweep.go.
+
+// podKind is the group version kind of the owner of endpoints published by
+// pods
+var podKind = corev1.SchemeGroupVersion.WithKind("Pod")
+
+// generatedSuffixLength is the length of the part of the UUID appended to the
+// name of the pod in generated names
+const generatedSuffixLength = 8
+
+// podOwnerReference returns the owner reference of an endpoint published by a
+// pod, or of a connection of a pod.
+func podOwnerReference(pod *corev1.Pod) meta.OwnerReference {
+	controller := true
+	return meta.OwnerReference{
+		APIVersion: podKind.GroupVersion().String(),
+		Kind:       podKind.Kind,
+		Name:       pod.Name,
+		UID:        pod.UID,
+		Controller: &controller,
+	}
+}
+
+// podOwner returns the owner reference of an object to its pod, or nil if the
+// object is not owned by a pod.
+func podOwner(objMeta *meta.ObjectMeta) *meta.OwnerReference {
+	for i := range objMeta.OwnerReferences {
+		ref := &objMeta.OwnerReferences[i]
+		if ref.APIVersion == podKind.GroupVersion().String() && ref.Kind == podKind.Kind {
+			return ref
+		}
+	}
+	return nil
+}
+
+// PublishEndpoint creates a NetworkServiceEndpoint with the given spec and
+// labels on behalf of a pod, in the namespace of the pod. The name of the
+// endpoint is generated from the name of the pod when the spec has none. The
+// endpoint is owned by the pod, its node and pod are filled in from the pod,
+// and its UUID and the NSM finalizer are set right away.
+func (plugin *Plugin) PublishEndpoint(namespace, podName string, spec *netmesh.NetworkServiceEndpoint, labels map[string]string) (*v1.NetworkServiceEndpoint, error) {
+	name := spec.Name
+	if !plugin.watchesNamespace(namespace) {
+		return nil, apierrors.NewForbidden(v1.Resource(v1.NSMEPPlural), name, fmt.Errorf("namespace '%s' is not watched", namespace))
+	}
+	pod, err := plugin.k8sClientset.CoreV1().Pods(namespace).Get(podName, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.DeletionTimestamp != nil {
+		return nil, apierrors.NewConflict(corev1.Resource("pods"), podName, fmt.Errorf("pod is being deleted"))
+	}
+	if pod.Spec.NodeName == "" {
+		return nil, apierrors.NewConflict(corev1.Resource("pods"), podName, fmt.Errorf("pod is not scheduled"))
+	}
+
+	nse := &v1.NetworkServiceEndpoint{
+		ObjectMeta: meta.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			Labels:          make(map[string]string, len(labels)+1),
+			OwnerReferences: []meta.OwnerReference{podOwnerReference(pod)},
+			Finalizers:      []string{v1.NSMFinalizer},
+		},
+		Spec: *spec.DeepCopy(),
+	}
+	for key, value := range labels {
+		nse.Labels[key] = value
+	}
+	nse.Labels[v1.NSMNodeLabel] = pod.Spec.NodeName
+	nse.Spec.Node = pod.Spec.NodeName
+	nse.Spec.Pod = &netmesh.PodReference{Name: pod.Name, Uid: string(pod.UID)}
+	nse.Spec.Uuid = ""
+	assignUUID(&nse.Spec.Uuid, "")
+	if name == "" {
+		nse.Name = generatedName(podName, nse.Spec.Uuid)
+	}
+	nse.Spec.Name = nse.Name
+
+	created, err := plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(namespace).Create(nse)
+	if err != nil {
+		return nil, err
+	}
+	plugin.Log.Infof("Pod '%s/%s' published NetworkServiceEndpoint '%s' with UUID %s",
+		namespace, podName, created.Name, created.Spec.Uuid)
+	return created, nil
+}
+
+// generatedName returns the name of an object created on behalf of a pod,
+// e.g. an endpoint published without a name, made of the name of the pod and
+// the beginning of the UUID of the object. The name of the pod is truncated
+// and its dots replaced to keep the name a valid DNS-1123 label.
+func generatedName(podName, id string) string {
+	suffix := id
+	if len(suffix) > generatedSuffixLength {
+		suffix = suffix[:generatedSuffixLength]
+	}
+	prefix := strings.Replace(podName, ".", "-", -1)
+	if max := validation.DNS1123LabelMaxLength - len(suffix) - 1; len(prefix) > max {
+		prefix = prefix[:max]
+	}
+	return strings.TrimRight(prefix, "-") + "-" + suffix
+}
+
+// checkPodOwner returns an error unless an object of a resource is owned by
+// the pod with the given namespace and name. The pod is looked up, so that a
+// pod recreated under the same name cannot act on the objects of the pod it
+// replaced. Objects of other namespaces are reported as not found.
+func checkPodOwner(plugin *Plugin, resource string, objMeta *meta.ObjectMeta, id, podNamespace, podName string) error {
+	if objMeta.Namespace != podNamespace {
+		return apierrors.NewNotFound(v1.Resource(resource), id)
+	}
+	forbidden := apierrors.NewForbidden(v1.Resource(resource), objMeta.Name,
+		fmt.Errorf("not owned by pod '%s/%s'", podNamespace, podName))
+	owner := podOwner(objMeta)
+	if owner == nil || owner.Name != podName {
+		return forbidden
+	}
+	pod, err := plugin.k8sClientset.CoreV1().Pods(podNamespace).Get(podName, meta.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return forbidden
+	} else if err != nil {
+		return err
+	}
+	if pod.UID != owner.UID {
+		return forbidden
+	}
+	return nil
+}
+
+// DelistEndpoint deletes the NetworkServiceEndpoint with the given UUID on
+// behalf of the pod owning it.
+func (plugin *Plugin) DelistEndpoint(podNamespace, podName, uuid string) error {
+	nse, err := plugin.GetNetworkServiceEndpointByUUID(uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkPodOwner(plugin, v1.NSMEPPlural, &nse.ObjectMeta, uuid, podNamespace, podName); err != nil {
+		return err
+	}
+	err = plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(nse.Namespace).Delete(nse.Name, &meta.DeleteOptions{
+		Preconditions: &meta.Preconditions{UID: &nse.UID},
+	})
+	if err != nil {
+		return err
+	}
+	plugin.Log.Infof("Delisted NetworkServiceEndpoint '%s/%s'", nse.Namespace, nse.Name)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+func TestCheckPodOwner(t *testing.T) {
+	plugin := newTestPlugin(t, time.Second)
+	pod, err := plugin.k8sClientset.CoreV1().Pods("default").Create(&corev1.Pod{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-pod", UID: "pod-uid"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recreated := podOwnerReference(pod)
+	recreated.UID = "old-pod-uid"
+	missing := podOwnerReference(pod)
+	missing.Name = "silver-pod"
+
+	tests := []struct {
+		name         string
+		owners       []meta.OwnerReference
+		podNamespace string
+		podName      string
+		// check tells the expected error, nil if the pod owns the object
+		check func(error) bool
+	}{
+		{"owner", []meta.OwnerReference{podOwnerReference(pod)}, "default", "gold-pod", nil},
+		{"other pod", []meta.OwnerReference{podOwnerReference(pod)}, "default", "silver-pod", apierrors.IsForbidden},
+		{"recreated pod", []meta.OwnerReference{recreated}, "default", "gold-pod", apierrors.IsForbidden},
+		{"missing pod", []meta.OwnerReference{missing}, "default", "silver-pod", apierrors.IsForbidden},
+		{"other namespace", []meta.OwnerReference{podOwnerReference(pod)}, "team-a", "gold-pod", apierrors.IsNotFound},
+		{"no owner", nil, "default", "gold-pod", apierrors.IsForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objMeta := &meta.ObjectMeta{Namespace: "default", Name: "gold-connection", OwnerReferences: test.owners}
+			err := checkPodOwner(plugin, v1.NSMConnectionPlural, objMeta, "5d8e1f2a", test.podNamespace, test.podName)
+			if test.check == nil {
+				if err != nil {
+					t.Errorf("expected the pod to own the object, got %s", err)
+				}
+			} else if !test.check(err) {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
@@ -12,259 +12,194 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// //go:generate protoc -I ./model/pod --go_out=plugins=grpc:./model/pod ./model/pod/pod.proto
-
 package netmeshplugincrd
 
 import (
-	"fmt"
-
-	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
 )
 
 // This file contains the work queue backends for each of the CRDs we create in the
-// plugin AfterInit() call.
-
-func networkserviceWork(plugin *Plugin) {
-	for {
-		// We read a message off the queue ...
-		key, shutdown := queueNS.Get()
-
-		// If the queue has been shut down, we should exit the work queue here
-		if shutdown {
-			plugin.stopChNS <- struct{}{}
-			return
-		}
-
-		// Convert the queue item into a string. If it's not a string, we'll
-		// simply discard it as invalid data and log a message.
-		var strKey string
-		var ok bool
-		if strKey, ok = key.(string); !ok {
-			runtime.HandleError(fmt.Errorf("key in queue should be of type string but got %T. discarding", key))
-			return
-		}
-
-		// We define a function here to process a queue item, so that we can
-		// use 'defer' to make sure the message is marked as Done on the queue
-		func(key string) {
-			var obj interface{}
-			var err error
-
-			defer queueNS.Done(key)
-
-			// Attempt to split the 'key' into namespace and object name
-			namespace, name, err := cache.SplitMetaNamespaceKey(strKey)
-
-			if err != nil {
-				plugin.Log.Errorf("Error splitting meta namespace key into parts: %s", err.Error())
-				runtime.HandleError(fmt.Errorf("Error splitting meta namespace key into parts: %s", err.Error()))
-				// This is a soft-error, we merely want to forget it and mark it done on the queue
-				queueNS.Forget(key)
-				return
-			}
-
-			plugin.Log.Infof("Read item '%s/%s' off workqueue. Processing...", namespace, name)
-
-			// Retrieve the latest version in the cache of this alert
-			obj, err = plugin.sharedFactoryNS.Networkservice().V1().NetworkServices().Lister().NetworkServices(namespace).Get(name)
-
-			if err != nil {
-				plugin.Log.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error())
-				runtime.HandleError(fmt.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error()))
-				// This is a hard-error, we'll raise the flag so the plugin catches this and shuts down
-				queueNS.Forget(key)
-				plugin.queueError <- true
-				return
-			}
-
-			plugin.Log.Infof("Got most up to date version of '%s/%s'. Syncing...", namespace, name)
-			plugin.Log.Infof("Object found: %s", obj)
-			plugin.Log.Infof("Finished processing '%s/%s' successfully! Removing from queue.", namespace, name)
-
-			// As we managed to process this successfully, we can forget it
-			// from the work queue altogether.
-			queueNS.Forget(key)
-		}(strKey)
-	}
+// plugin AfterInit() call. Each of them is a Reconciler driven by its own
+// Controller.
+
+// newNetworkServiceController creates the controller of NetworkServices.
+// NetworkServices are reconciled against the channels, the endpoints and the
+// namespaces, so the controller waits for those caches as well.
+func newNetworkServiceController(plugin *Plugin) *Controller {
+	informers := plugin.sharedFactory.Networkservice().V1().NetworkServices()
+	lister := informers.Lister()
+
+	return NewController(ControllerConfig{
+		Name:     "NetworkService",
+		Informer: informers.Informer(),
+		Lookup: func(namespace, name string) (interface{}, error) {
+			return lister.NetworkServices(namespace).Get(name)
+		},
+		Reconciler:  &networkserviceReconciler{plugin: plugin},
+		Workers:     plugin.config.Workers.NetworkServices,
+		RateLimiter: newRateLimiter(plugin),
+		Filter:      plugin.watchesObject,
+		WaitFor: []cache.InformerSynced{
+			plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Informer().HasSynced,
+			plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().HasSynced,
+			plugin.namespaceInformer.HasSynced,
+		},
+		OnError: plugin.queueFailed,
+		Log:     plugin.Log,
+	})
 }
 
-// networkserviceEnqeue will add an object 'obj' into the workqueue. The object being added
-// must be of type metav1.Object, metav1.ObjectAccessor or cache.ExplicitKey.
-func networkserviceEnqueue(obj interface{}) {
-	// DeletionHandlingMetaNamespaceKeyFunc will convert an object into a
-	// 'namespace/name' string. We do this because our item may be processed
-	// much later than now, and so we want to ensure it gets a fresh copy of
-	// the resource when it starts. Also, this allows us to keep adding the
-	// same item into the work queue without duplicates building up.
-	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err != nil {
-		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueue: %s", err.Error()))
-		return
-	}
-	// Add the item to the queue
-	queueNS.Add(key)
+// newNetworkServiceChannelController creates the controller of
+// NetworkServiceChannels.
+func newNetworkServiceChannelController(plugin *Plugin) *Controller {
+	informers := plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels()
+	lister := informers.Lister()
+
+	return NewController(ControllerConfig{
+		Name:     "NetworkServiceChannel",
+		Informer: informers.Informer(),
+		Lookup: func(namespace, name string) (interface{}, error) {
+			return lister.NetworkServiceChannels(namespace).Get(name)
+		},
+		Reconciler:  &networkservicechannelReconciler{plugin: plugin},
+		Workers:     plugin.config.Workers.NetworkServiceChannels,
+		RateLimiter: newRateLimiter(plugin),
+		Filter:      plugin.watchesObject,
+		WaitFor: []cache.InformerSynced{
+			plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().HasSynced,
+		},
+		OnError: plugin.queueFailed,
+		Log:     plugin.Log,
+	})
 }
 
-func networkservicechannelWork(plugin *Plugin) {
-	for {
-		// We read a message off the queue
-		key, shutdown := queueNSC.Get()
-
-		// If the queue has been shut down, we should exit the work queue here
-		if shutdown {
-			plugin.stopChNSC <- struct{}{}
-			return
-		}
-
-		// Convert the queue item into a string. If it's not a string, we'll
-		// simply discard it as invalid data and log a message.
-		var strKey string
-		var ok bool
-		if strKey, ok = key.(string); !ok {
-			runtime.HandleError(fmt.Errorf("key in queue should be of type string but got %T. discarding", key))
-			return
-		}
-
-		// We define a function here to process a queue item, so that we can
-		// use 'defer' to make sure the message is marked as Done on the queue
-		func(key string) {
-			var obj interface{}
-			var err error
-
-			defer queueNSC.Done(key)
-
-			// Attempt to split the 'key' into namespace and object name
-			namespace, name, err := cache.SplitMetaNamespaceKey(strKey)
-
-			if err != nil {
-				plugin.Log.Errorf("Error splitting meta namespace key into parts: %s", err.Error())
-				runtime.HandleError(fmt.Errorf("error splitting meta namespace key into parts: %s", err.Error()))
-				// This is a soft-error, we merely want to forget it and mark it done on the queue
-				queueNSC.Forget(key)
-				return
-			}
-
-			plugin.Log.Infof("Read item '%s/%s' off workqueue. Processing...", namespace, name)
-
-			// Retrieve the latest version in the cache of this alert
-			obj, err = plugin.sharedFactoryNSC.Networkservice().V1().NetworkServiceChannels().Lister().NetworkServiceChannels(namespace).Get(name)
-
-			if err != nil {
-				plugin.Log.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error())
-				runtime.HandleError(fmt.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error()))
-				// This is a hard-error, we'll raise the flag so the plugin catches this and shuts down
-				queueNSC.Forget(key)
-				plugin.queueError <- true
-				return
-			}
-
-			plugin.Log.Infof("Got most up to date version of '%s/%s'. Syncing...", namespace, name)
-			plugin.Log.Infof("Object found: %s", obj)
-			plugin.Log.Infof("Finished processing '%s/%s' successfully! Removing from queue.", namespace, name)
-
-			// As we managed to process this successfully, we can forget it
-			// from the work queue altogether.
-			queueNSC.Forget(key)
-		}(strKey)
-	}
+// newNetworkServiceEndpointController creates the controller of
+// NetworkServiceEndpoints.
+func newNetworkServiceEndpointController(plugin *Plugin) *Controller {
+	informers := plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints()
+	lister := informers.Lister()
+
+	return NewController(ControllerConfig{
+		Name:     "NetworkServiceEndpoint",
+		Informer: informers.Informer(),
+		Lookup: func(namespace, name string) (interface{}, error) {
+			return lister.NetworkServiceEndpoints(namespace).Get(name)
+		},
+		Reconciler:  &networkserviceendpointReconciler{plugin: plugin},
+		Workers:     plugin.config.Workers.NetworkServiceEndpoints,
+		RateLimiter: newRateLimiter(plugin),
+		Filter:      plugin.watchesObject,
+		WaitFor: []cache.InformerSynced{
+			plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().HasSynced,
+		},
+		OnError: plugin.queueFailed,
+		Log:     plugin.Log,
+	})
 }
 
-// networkserviceChannelEnqueue will add an object 'obj' into the workqueue. The object being added
-// must be of type metav1.Object, metav1.ObjectAccessor or cache.ExplicitKey.
-func networkservicechannelEnqueue(obj interface{}) {
-	// DeletionHandlingMetaNamespaceKeyFunc will convert an object into a
-	// 'namespace/name' string. We do this because our item may be processed
-	// much later than now, and so we want to ensure it gets a fresh copy of
-	// the resource when it starts. Also, this allows us to keep adding the
-	// same item into the work queue without duplicates building up.
-	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err != nil {
-		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueue: %s", err.Error()))
-		return
-	}
-	// Add the item to the queue
-	queueNSC.Add(key)
+// newNetworkServiceConnectionController creates the controller of
+// NetworkServiceConnections. Connections are reconciled against their
+// service, endpoint and channel, so the controller waits for those caches as
+// well.
+func newNetworkServiceConnectionController(plugin *Plugin) *Controller {
+	informers := plugin.sharedFactory.Networkservice().V1().NetworkServiceConnections()
+	lister := informers.Lister()
+
+	return NewController(ControllerConfig{
+		Name:     "NetworkServiceConnection",
+		Informer: informers.Informer(),
+		Lookup: func(namespace, name string) (interface{}, error) {
+			return lister.NetworkServiceConnections(namespace).Get(name)
+		},
+		Reconciler:  &networkserviceconnectionReconciler{plugin: plugin},
+		Workers:     plugin.config.Workers.NetworkServiceConnections,
+		RateLimiter: newRateLimiter(plugin),
+		Filter:      plugin.watchesObject,
+		WaitFor: []cache.InformerSynced{
+			plugin.sharedFactory.Networkservice().V1().NetworkServices().Informer().HasSynced,
+			plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Informer().HasSynced,
+			plugin.sharedFactory.Networkservice().V1().NetworkServiceEndpoints().Informer().HasSynced,
+		},
+		OnError: plugin.queueFailed,
+		Log:     plugin.Log,
+	})
 }
 
-func networkserviceendpointWork(plugin *Plugin) {
-	for {
-		// We read a message off the queue
-		key, shutdown := queueNSE.Get()
+// newRateLimiter creates the backoff of the controllers from the
+// configuration.
+func newRateLimiter(plugin *Plugin) workqueue.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(plugin.config.MinRetryPeriod.Duration, plugin.config.MaxRetryPeriod.Duration)
+}
 
-		// If the queue has been shut down, we should exit the work queue here
-		if shutdown {
-			plugin.stopChNSE <- struct{}{}
-			return
-		}
+// networkserviceReconciler is the Reconciler of NetworkServices.
+type networkserviceReconciler struct {
+	plugin *Plugin
+}
 
-		// Convert the queue item into a string. If it's not a string, we'll
-		// simply discard it as invalid data and log a message.
-		var strKey string
-		var ok bool
-		if strKey, ok = key.(string); !ok {
-			runtime.HandleError(fmt.Errorf("key in queue should be of type string but got %T. discarding", key))
-			return
-		}
+// Reconcile updates the status of a NetworkService.
+func (r *networkserviceReconciler) Reconcile(namespace, name string, obj interface{}) error {
+	return reconcileNetworkService(r.plugin, obj.(*v1.NetworkService))
+}
 
-		// We define a function here to process a queue item, so that we can
-		// use 'defer' to make sure the message is marked as Done on the queue
-		func(key string) {
-			var obj interface{}
-			var err error
+// Delete runs the cleanup of a deleted NetworkService.
+func (r *networkserviceReconciler) Delete(namespace, name string, obj interface{}) error {
+	return networkserviceDeleted(r.plugin, namespace, name, obj)
+}
 
-			defer queueNSE.Done(key)
+// networkservicechannelReconciler is the Reconciler of NetworkServiceChannels.
+type networkservicechannelReconciler struct {
+	plugin *Plugin
+}
 
-			// Attempt to split the 'key' into namespace and object name
-			namespace, name, err := cache.SplitMetaNamespaceKey(strKey)
+// Reconcile updates the status of a NetworkServiceChannel.
+func (r *networkservicechannelReconciler) Reconcile(namespace, name string, obj interface{}) error {
+	return reconcileNetworkServiceChannel(r.plugin, obj.(*v1.NetworkServiceChannel))
+}
 
-			if err != nil {
-				plugin.Log.Errorf("Error splitting meta namespace key into parts: %s", err.Error())
-				runtime.HandleError(fmt.Errorf("error splitting meta namespace key into parts: %s", err.Error()))
-				// This is a soft-error, we merely want to forget it and mark it done on the queue
-				queueNSE.Forget(key)
-				return
-			}
+// Delete runs the cleanup of a deleted NetworkServiceChannel.
+func (r *networkservicechannelReconciler) Delete(namespace, name string, obj interface{}) error {
+	return networkservicechannelDeleted(r.plugin, namespace, name, obj)
+}
 
-			plugin.Log.Infof("Read item '%s/%s' off workqueue. Processing...", namespace, name)
+// networkserviceendpointReconciler is the Reconciler of
+// NetworkServiceEndpoints.
+type networkserviceendpointReconciler struct {
+	plugin *Plugin
+}
 
-			// Retrieve the latest version in the cache of this alert
-			obj, err = plugin.sharedFactoryNSE.Networkservice().V1().NetworkServiceEndpoints().Lister().NetworkServiceEndpoints(namespace).Get(name)
+// Reconcile manages the finalizer of a NetworkServiceEndpoint.
+func (r *networkserviceendpointReconciler) Reconcile(namespace, name string, obj interface{}) error {
+	return reconcileNetworkServiceEndpoint(r.plugin, obj.(*v1.NetworkServiceEndpoint))
+}
 
-			if err != nil {
-				plugin.Log.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error())
-				runtime.HandleError(fmt.Errorf("Error getting object '%s/%s' from api: %s", namespace, name, err.Error()))
-				// This is a hard-error, we'll raise the flag so the plugin catches this and shuts down
-				queueNSE.Forget(key)
-				plugin.queueError <- true
-				return
-			}
+// Delete runs the cleanup of a deleted NetworkServiceEndpoint.
+func (r *networkserviceendpointReconciler) Delete(namespace, name string, obj interface{}) error {
+	return networkserviceendpointDeleted(r.plugin, namespace, name, obj)
+}
 
-			plugin.Log.Infof("Got most up to date version of '%s/%s'. Syncing...", namespace, name)
-			plugin.Log.Infof("Object found: %s", obj)
-			plugin.Log.Infof("Finished processing '%s/%s' successfully! Removing from queue.", namespace, name)
+// networkserviceconnectionReconciler is the Reconciler of
+// NetworkServiceConnections.
+type networkserviceconnectionReconciler struct {
+	plugin *Plugin
+}
 
-			// As we managed to process this successfully, we can forget it
-			// from the work queue altogether.
-			queueNSE.Forget(key)
-		}(strKey)
-	}
+// Reconcile updates the status of a NetworkServiceConnection.
+func (r *networkserviceconnectionReconciler) Reconcile(namespace, name string, obj interface{}) error {
+	return reconcileNetworkServiceConnection(r.plugin, obj.(*v1.NetworkServiceConnection))
 }
 
-// networkserviceEndpointEnqueue will add an object 'obj' into the workqueue. The object being added
-// must be of type metav1.Object, metav1.ObjectAccessor or cache.ExplicitKey.
-func networkserviceendpointEnqueue(obj interface{}) {
-	// DeletionHandlingMetaNamespaceKeyFunc will convert an object into a
-	// 'namespace/name' string. We do this because our item may be processed
-	// much later than now, and so we want to ensure it gets a fresh copy of
-	// the resource when it starts. Also, this allows us to keep adding the
-	// same item into the work queue without duplicates building up.
-	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err != nil {
-		runtime.HandleError(fmt.Errorf("error obtaining key for object being enqueue: %s", err.Error()))
-		return
-	}
-	// Add the item to the queue
-	queueNSE.Add(key)
+// Delete runs the cleanup of a deleted NetworkServiceConnection.
+func (r *networkserviceconnectionReconciler) Delete(namespace, name string, obj interface{}) error {
+	return networkserviceconnectionDeleted(r.plugin, namespace, name, obj)
 }
+
+// Compile time check that the reconcilers implement the interface
+var (
+	_ Reconciler = &networkserviceReconciler{}
+	_ Reconciler = &networkservicechannelReconciler{}
+	_ Reconciler = &networkserviceendpointReconciler{}
+	_ Reconciler = &networkserviceconnectionReconciler{}
+)
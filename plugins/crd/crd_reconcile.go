@@ -0,0 +1,231 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/selector"
+)
+
+// This file contains the reconciliation logic run by the work queue backends
+// once the latest version of an object has been read from the cache.
+
+// reconcileNetworkService resolves the channels and the endpoints referenced by
+// a NetworkService and records the outcome in its status. The object passed in
+// comes from the informer cache and must not be modified.
+func reconcileNetworkService(plugin *Plugin, ns *v1.NetworkService) error {
+	// The status is written by the leader only, the object is requeued when
+	// this instance becomes the leader
+	if !plugin.IsLeader() {
+		plugin.Log.Debugf("Not the leader, skipping status of '%s/%s'", ns.Namespace, ns.Name)
+		return nil
+	}
+	if ns.DeletionTimestamp != nil {
+		return finalizeNetworkService(plugin, ns)
+	}
+	// The update of the object requeues it, the status is computed then
+	if updated, err := initializeNetworkService(plugin, ns); err != nil || updated {
+		return err
+	}
+
+	status, err := networkServiceStatus(plugin, ns)
+	if err != nil {
+		return err
+	}
+	for _, channel := range ns.Spec.Channels {
+		if channel != nil {
+			status.ChannelCount++
+		}
+	}
+	status.Conditions = v1.SetCondition(ns.Status.Conditions, readyCondition(ns.Generation, status.State, status.Message))
+	status.UUID = ns.Status.UUID
+
+	if reflect.DeepEqual(ns.Status, status) {
+		plugin.Log.Debugf("Status of '%s/%s' is up to date: %s", ns.Namespace, ns.Name, status.State)
+		return nil
+	}
+
+	nsCopy := ns.DeepCopy()
+	nsCopy.Status = status
+	if _, err = plugin.crdClient.NetworkserviceV1().NetworkServices(ns.Namespace).UpdateStatus(nsCopy); err != nil {
+		return fmt.Errorf("error updating status of '%s/%s': %s", ns.Namespace, ns.Name, err)
+	}
+	plugin.Log.Infof("NetworkService '%s/%s' is %s: %s", ns.Namespace, ns.Name, status.State, status.Message)
+	recordStateChange(plugin, ns, ns.Status.State, status.State, status.Message)
+	recordEndpointsChanged(plugin, ns, ns.Status.Endpoints, status.Endpoints)
+
+	return nil
+}
+
+// networkServiceStatus computes the status of a NetworkService from the
+// channels and endpoints currently present in the informer caches.
+func networkServiceStatus(plugin *Plugin, ns *v1.NetworkService) (v1.NetworkServiceStatus, error) {
+	channelLister := plugin.sharedFactory.Networkservice().V1().NetworkServiceChannels().Lister()
+
+	// Every channel of the service must exist as a NetworkServiceChannel in
+	// the same namespace, and carry the payload the service requests from
+	// it, if any.
+	var missing, mismatched []string
+	for _, channel := range ns.Spec.Channels {
+		if channel == nil {
+			continue
+		}
+		nsc, err := channelLister.NetworkServiceChannels(ns.Namespace).Get(channel.Name)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, channel.Name)
+			continue
+		} else if err != nil {
+			return v1.NetworkServiceStatus{}, err
+		}
+		requested, err := channel.PayloadType()
+		if err == nil {
+			_, err = nsc.Spec.Adapt(requested)
+		}
+		if err != nil {
+			mismatched = append(mismatched, err.Error())
+		}
+	}
+	if len(missing) > 0 {
+		return v1.NetworkServiceStatus{
+			State:   v1.NetworkServiceStateMissingChannel,
+			Message: fmt.Sprintf("channels not found: %s", strings.Join(missing, ", ")),
+		}, nil
+	}
+	if len(mismatched) > 0 {
+		return v1.NetworkServiceStatus{
+			State:   v1.NetworkServiceStatePayloadMismatch,
+			Message: strings.Join(mismatched, "; "),
+		}, nil
+	}
+
+	// Prefixes must be valid and must not overlap the prefixes of older
+	// services, see crd_ipam.go
+	state, message, err := prefixStatus(plugin, ns)
+	if err != nil {
+		return v1.NetworkServiceStatus{}, err
+	}
+	if state != "" {
+		return v1.NetworkServiceStatus{State: state, Message: message}, nil
+	}
+
+	// Imported services must exist and be exported to the namespace of the
+	// service
+	imported, state, message, err := resolveImports(plugin, ns)
+	if err != nil {
+		return v1.NetworkServiceStatus{}, err
+	}
+	if state != "" {
+		return v1.NetworkServiceStatus{State: state, Message: message}, nil
+	}
+
+	// The selector string and the label selector are matched against the
+	// labels of the endpoints in the same namespace, and the selectors of the
+	// imported services against the endpoints of their namespace.
+	sel, err := selector.ForService(&ns.Spec)
+	if err != nil {
+		return v1.NetworkServiceStatus{
+			State:   v1.NetworkServiceStateInvalidSelector,
+			Message: err.Error(),
+		}, nil
+	}
+	endpoints, err := selectedEndpoints(plugin, ns, imported)
+	if err != nil {
+		return v1.NetworkServiceStatus{}, err
+	}
+	if len(endpoints) == 0 {
+		return v1.NetworkServiceStatus{
+			State:   v1.NetworkServiceStateNoEndpoints,
+			Message: fmt.Sprintf("no endpoints match selector %q", sel.String()),
+		}, nil
+	}
+
+	names := endpointNames(ns, endpoints)
+	return v1.NetworkServiceStatus{
+		State:         v1.NetworkServiceStateReady,
+		Message:       fmt.Sprintf("endpoints: %s", strings.Join(names, ", ")),
+		EndpointCount: int32(len(names)),
+		Endpoints:     names,
+	}, nil
+}
+
+// readyCondition returns the Ready condition matching a state computed by the
+// reconciler. The condition is True for the Ready state of services, the
+// Healthy state of endpoints and the Established state of connections only,
+// the state is used as the reason in any case.
+func readyCondition(generation int64, state, message string) v1.Condition {
+	status := v1.ConditionFalse
+	if state == v1.NetworkServiceStateReady || state == v1.NetworkServiceEndpointStateHealthy ||
+		state == v1.NetworkServiceConnectionStateEstablished {
+		status = v1.ConditionTrue
+	}
+	return v1.Condition{
+		Type:               v1.ConditionReady,
+		Status:             status,
+		Reason:             state,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+}
+
+// reconcileNetworkServiceChannel records in the status of a
+// NetworkServiceChannel whether services use it. The object passed in comes
+// from the informer cache and must not be modified.
+func reconcileNetworkServiceChannel(plugin *Plugin, nsc *v1.NetworkServiceChannel) error {
+	if !plugin.IsLeader() {
+		plugin.Log.Debugf("Not the leader, skipping status of '%s/%s'", nsc.Namespace, nsc.Name)
+		return nil
+	}
+
+	services, err := servicesUsingChannel(plugin, nsc.Namespace, nsc.Name)
+	if err != nil {
+		return fmt.Errorf("error looking up NetworkServices using '%s/%s': %s", nsc.Namespace, nsc.Name, err)
+	}
+	status := v1.NetworkServiceChannelStatus{
+		State:      v1.NetworkServiceChannelStateUnused,
+		Message:    "not used by any service",
+		Conditions: nsc.Status.Conditions,
+	}
+	if len(services) > 0 {
+		names := make([]string, 0, len(services))
+		for _, ns := range services {
+			names = append(names, ns.Name)
+		}
+		sort.Strings(names)
+		status.State = v1.NetworkServiceChannelStateInUse
+		status.Message = fmt.Sprintf("used by: %s", strings.Join(names, ", "))
+	}
+
+	if reflect.DeepEqual(nsc.Status, status) {
+		plugin.Log.Debugf("Status of '%s/%s' is up to date: %s", nsc.Namespace, nsc.Name, status.State)
+		return nil
+	}
+
+	nscCopy := nsc.DeepCopy()
+	nscCopy.Status = status
+	if _, err = plugin.crdClient.NetworkserviceV1().NetworkServiceChannels(nsc.Namespace).UpdateStatus(nscCopy); err != nil {
+		return fmt.Errorf("error updating status of '%s/%s': %s", nsc.Namespace, nsc.Name, err)
+	}
+	plugin.Log.Infof("NetworkServiceChannel '%s/%s' is %s: %s", nsc.Namespace, nsc.Name, status.State, status.Message)
+	recordStateChange(plugin, nsc, nsc.Status.State, status.State, status.Message)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the orphan sweeper. The Kubernetes garbage collector
+// deletes the endpoints owned by a pod once the pod is deleted, yet endpoints
+// outlive their pod when the garbage collector lags behind or the owner
+// reference was removed, and outlive their node when the node is removed
+// without its pods being deleted. The leader periodically deletes such
+// endpoints, which are then finalized like any other endpoint.
+
+// runOrphanSweeper sweeps the orphaned endpoints every OrphanSweepPeriod until
+// stopCh is closed.
+func runOrphanSweeper(plugin *Plugin, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		// The caches are needed to list the endpoints, and only the
+		// leader writes the cluster-wide state
+		if !plugin.HasSynced() || !plugin.IsLeader() {
+			return
+		}
+		if err := sweepOrphans(plugin); err != nil {
+			plugin.Log.Errorf("Error sweeping orphaned NetworkServiceEndpoints: %s", err)
+		}
+	}, plugin.config.OrphanSweepPeriod.Duration, stopCh)
+}
+
+// sweepOrphans deletes the endpoints whose owning pod or node no longer
+// exists.
+func sweepOrphans(plugin *Plugin) error {
+	endpoints, err := plugin.ListNetworkServiceEndpoints("")
+	if err != nil {
+		return fmt.Errorf("error listing NetworkServiceEndpoints: %s", err)
+	}
+
+	sweep := &orphanSweep{plugin: plugin}
+	for _, nse := range endpoints {
+		if nse.DeletionTimestamp != nil {
+			continue
+		}
+
+		reason, err := sweep.reason(nse)
+		if err != nil {
+			return err
+		}
+		if reason == "" {
+			continue
+		}
+
+		err = plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(nse.Namespace).Delete(nse.Name, &meta.DeleteOptions{
+			Preconditions: &meta.Preconditions{UID: &nse.UID},
+		})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return fmt.Errorf("error deleting orphaned '%s/%s': %s", nse.Namespace, nse.Name, err)
+		}
+		if err == nil {
+			plugin.Log.Warnf("Deleted orphaned NetworkServiceEndpoint '%s/%s': %s", nse.Namespace, nse.Name, reason)
+			plugin.recorder.Eventf(nse, corev1.EventTypeWarning, EventReasonOrphaned, "Deleted orphaned endpoint: %s", reason)
+		}
+	}
+
+	return nil
+}
+
+// orphanSweep holds the state of a sweep of the orphaned endpoints.
+type orphanSweep struct {
+	plugin *Plugin
+	// nodes is the set of existing nodes, listed on the first endpoint
+	// running on a node
+	nodes map[string]bool
+}
+
+// reason returns why an endpoint is orphaned, or an empty string if it is
+// not.
+func (s *orphanSweep) reason(nse *v1.NetworkServiceEndpoint) (string, error) {
+	if owner := podOwner(&nse.ObjectMeta); owner != nil {
+		pod, err := s.plugin.k8sClientset.CoreV1().Pods(nse.Namespace).Get(owner.Name, meta.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("pod %s no longer exists", owner.Name), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("error getting pod '%s/%s': %s", nse.Namespace, owner.Name, err)
+		}
+		if pod.UID != owner.UID {
+			// A pod with the same name replaced the owner
+			return fmt.Sprintf("pod %s with UID %s no longer exists", owner.Name, owner.UID), nil
+		}
+	}
+
+	node := endpointNode(nse)
+	if node == "" {
+		return "", nil
+	}
+	if s.nodes == nil {
+		list, err := s.plugin.k8sClientset.CoreV1().Nodes().List(meta.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("error listing nodes: %s", err)
+		}
+		s.nodes = make(map[string]bool, len(list.Items))
+		for _, item := range list.Items {
+			s.nodes[item.Name] = true
+		}
+	}
+	if !s.nodes[node] {
+		return fmt.Sprintf("node %s no longer exists", node), nil
+	}
+	return "", nil
+}
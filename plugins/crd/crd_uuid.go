@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"fmt"
+
+	"github.com/satori/go.uuid"
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// This file contains the assignment of the UUIDs of NetworkServices,
+// NetworkServiceEndpoints and NetworkServiceConnections. The leader assigns a
+// UUID to the objects created without one, along with the NSM finalizer for
+// services and endpoints, and records it in their status. The UUID is
+// immutable from then on, a changed UUID is restored from the status. Unlike
+// the spec and the metadata, the status is only written through the status
+// subresource, by the plugin, so it cannot be changed along with the spec.
+
+// hasUUID returns true if the UUID of the spec is set and matches the one
+// recorded in the status, if any.
+func hasUUID(id, recorded string) bool {
+	return id != "" && (recorded == "" || id == recorded)
+}
+
+// assignedUUID returns the UUID of an object, the recorded one if any.
+func assignedUUID(id, recorded string) string {
+	if recorded != "" {
+		return recorded
+	}
+	return id
+}
+
+// assignUUID makes the UUID of the spec match the recorded one, generating
+// it if none is set. It returns the previous UUID if it had to be restored.
+func assignUUID(id *string, recorded string) (restored string) {
+	switch {
+	case recorded != "" && *id != recorded:
+		restored = *id
+		*id = recorded
+	case *id == "":
+		*id = uuid.NewV4().String()
+	}
+	return restored
+}
+
+// initialization describes how an object is initialized, see initialize.
+type initialization struct {
+	// kind of the object, in the logs
+	kind string
+	// finalizer is set for the objects carrying the NSM finalizer
+	finalizer bool
+	// objMeta and id are the metadata and the UUID of the spec of a copy
+	// of the object
+	objMeta *meta.ObjectMeta
+	id      *string
+	// recorded is the UUID recorded in the status of the object
+	recorded string
+	// update updates the copy of the object
+	update func() error
+	// recordUUID updates the status of the copy of the object with its UUID
+	recordUUID func() error
+}
+
+// initialize adds the NSM finalizer to an object and assigns its UUID, then
+// records the UUID in its status. obj is the object from the informer cache.
+// It returns true if the object was updated, the update requeues the object.
+func initialize(plugin *Plugin, obj runtime.Object, init initialization) (bool, error) {
+	namespace, name := init.objMeta.Namespace, init.objMeta.Name
+	if (init.finalizer && !hasFinalizer(init.objMeta.Finalizers)) || !hasUUID(*init.id, init.recorded) {
+		if init.finalizer && !hasFinalizer(init.objMeta.Finalizers) {
+			init.objMeta.Finalizers = append(init.objMeta.Finalizers, v1.NSMFinalizer)
+		}
+		restored := assignUUID(init.id, init.recorded)
+		if err := init.update(); err != nil {
+			return false, fmt.Errorf("error initializing '%s/%s': %s", namespace, name, err)
+		}
+		if restored != "" {
+			plugin.Log.Warnf("UUID of %s '%s/%s' is immutable, restored %s instead of %s",
+				init.kind, namespace, name, *init.id, restored)
+			plugin.recorder.Eventf(obj, corev1.EventTypeWarning, EventReasonUUIDRestored,
+				"UUID is immutable, restored %s instead of %s", *init.id, restored)
+		}
+		plugin.Log.Debugf("Initialized %s '%s/%s' with UUID %s", init.kind, namespace, name, *init.id)
+		return true, nil
+	}
+
+	if init.recorded == "" {
+		if err := init.recordUUID(); err != nil {
+			return false, fmt.Errorf("error recording UUID of '%s/%s': %s", namespace, name, err)
+		}
+		plugin.Log.Debugf("Recorded UUID %s of %s '%s/%s'", *init.id, init.kind, namespace, name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// initializeNetworkService initializes a NetworkService, see initialize.
+func initializeNetworkService(plugin *Plugin, ns *v1.NetworkService) (bool, error) {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServices(ns.Namespace)
+	nsCopy := ns.DeepCopy()
+	return initialize(plugin, ns, initialization{
+		kind:      "NetworkService",
+		finalizer: true,
+		objMeta:   &nsCopy.ObjectMeta,
+		id:        &nsCopy.Spec.Uuid,
+		recorded:  ns.Status.UUID,
+		update: func() error {
+			_, err := client.Update(nsCopy)
+			return err
+		},
+		recordUUID: func() error {
+			nsCopy.Status.UUID = nsCopy.Spec.Uuid
+			_, err := client.UpdateStatus(nsCopy)
+			return err
+		},
+	})
+}
+
+// initializeNetworkServiceEndpoint initializes a NetworkServiceEndpoint, see
+// initialize.
+func initializeNetworkServiceEndpoint(plugin *Plugin, nse *v1.NetworkServiceEndpoint) (bool, error) {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServiceEndpoints(nse.Namespace)
+	nseCopy := nse.DeepCopy()
+	return initialize(plugin, nse, initialization{
+		kind:      "NetworkServiceEndpoint",
+		finalizer: true,
+		objMeta:   &nseCopy.ObjectMeta,
+		id:        &nseCopy.Spec.Uuid,
+		recorded:  nse.Status.UUID,
+		update: func() error {
+			_, err := client.Update(nseCopy)
+			return err
+		},
+		recordUUID: func() error {
+			nseCopy.Status.UUID = nseCopy.Spec.Uuid
+			_, err := client.UpdateStatus(nseCopy)
+			return err
+		},
+	})
+}
+
+// initializeNetworkServiceConnection initializes a NetworkServiceConnection,
+// see initialize. Connections do not carry the NSM finalizer, they are deleted
+// along with their service or endpoint.
+func initializeNetworkServiceConnection(plugin *Plugin, conn *v1.NetworkServiceConnection) (bool, error) {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServiceConnections(conn.Namespace)
+	connCopy := conn.DeepCopy()
+	return initialize(plugin, conn, initialization{
+		kind:     "NetworkServiceConnection",
+		objMeta:  &connCopy.ObjectMeta,
+		id:       &connCopy.Spec.Uuid,
+		recorded: conn.Status.UUID,
+		update: func() error {
+			_, err := client.Update(connCopy)
+			return err
+		},
+		recordUUID: func() error {
+			connCopy.Status.UUID = connCopy.Spec.Uuid
+			_, err := client.UpdateStatus(connCopy)
+			return err
+		},
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"testing"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+)
+
+// initializeTestService initializes the NetworkService as stored by the fake
+// clientset and returns it once updated.
+func initializeTestService(t *testing.T, plugin *Plugin, name string, updated bool) *v1.NetworkService {
+	client := plugin.crdClient.NetworkserviceV1().NetworkServices("default")
+	ns, err := client.Get(name, meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wasUpdated, err := initializeNetworkService(plugin, ns)
+	if err != nil {
+		t.Fatalf("error initializing NetworkService: %s", err)
+	}
+	if wasUpdated != updated {
+		t.Fatalf("expected the NetworkService to be updated: %t, got %t", updated, wasUpdated)
+	}
+	ns, err = client.Get(name, meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ns
+}
+
+func TestInitializeAssignsUUID(t *testing.T) {
+	ns := testService()
+	plugin := newTestPlugin(t, time.Second, ns)
+
+	// The UUID is assigned along with the finalizer, then recorded
+	initialized := initializeTestService(t, plugin, ns.Name, true)
+	if initialized.Spec.Uuid == "" || !hasFinalizer(initialized.Finalizers) {
+		t.Fatalf("expected a UUID and the NSM finalizer, got %q and %v", initialized.Spec.Uuid, initialized.Finalizers)
+	}
+	recorded := initializeTestService(t, plugin, ns.Name, true)
+	if recorded.Status.UUID != initialized.Spec.Uuid {
+		t.Fatalf("expected UUID %s to be recorded, got %q", initialized.Spec.Uuid, recorded.Status.UUID)
+	}
+	initializeTestService(t, plugin, ns.Name, false)
+}
+
+func TestInitializeKeepsUUID(t *testing.T) {
+	ns := testService()
+	ns.Spec.Uuid = "1f0e6a66-53f7-4b5c-9a8e-2a1f6c3d9e21"
+	plugin := newTestPlugin(t, time.Second, ns)
+
+	initialized := initializeTestService(t, plugin, ns.Name, true)
+	initialized = initializeTestService(t, plugin, ns.Name, true)
+	if initialized.Spec.Uuid != ns.Spec.Uuid || initialized.Status.UUID != ns.Spec.Uuid {
+		t.Fatalf("expected UUID %s to be kept, got %q recorded as %q", ns.Spec.Uuid, initialized.Spec.Uuid, initialized.Status.UUID)
+	}
+}
+
+func TestInitializeRestoresUUID(t *testing.T) {
+	ns := testService()
+	// The event recorded on restoring the UUID refers to the object by its
+	// self link
+	ns.SelfLink = "/apis/networkservicemesh.io/v1/namespaces/default/networkservices/gold-network"
+	plugin := newTestPlugin(t, time.Second, ns)
+	initializeTestService(t, plugin, ns.Name, true)
+	initialized := initializeTestService(t, plugin, ns.Name, true)
+
+	// The spec is changed along with the metadata, the UUID recorded in the
+	// status still wins
+	changed := initialized.DeepCopy()
+	changed.Spec.Uuid = "3c9b2d7e-8f41-4e6a-b5d2-7a0c1e9f4b36"
+	changed.Annotations = map[string]string{v1.NSMGroup + "/uuid": changed.Spec.Uuid}
+	if _, err := plugin.crdClient.NetworkserviceV1().NetworkServices("default").Update(changed); err != nil {
+		t.Fatal(err)
+	}
+	if indexed, _ := indexServiceByUUID(changed); len(indexed) != 1 || indexed[0] != initialized.Spec.Uuid {
+		t.Errorf("expected the NetworkService to be indexed by UUID %s, got %v", initialized.Spec.Uuid, indexed)
+	}
+
+	restored := initializeTestService(t, plugin, ns.Name, true)
+	if restored.Spec.Uuid != initialized.Spec.Uuid || restored.Status.UUID != initialized.Spec.Uuid {
+		t.Fatalf("expected UUID %s to be restored, got %q recorded as %q",
+			initialized.Spec.Uuid, restored.Spec.Uuid, restored.Status.UUID)
+	}
+	initializeTestService(t, plugin, ns.Name, false)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/selector"
+)
+
+// This file contains the OpenAPI v3 schemas the API server validates NSM
+// objects against. The schemas of the specs are derived from the netmesh model
+// types, and then constrained with required fields, patterns and enumerations.
+
+// networkServiceValidation returns the validation of the NetworkService CRD.
+func networkServiceValidation() *apiextv1beta1.CustomResourceValidation {
+	spec := schemaOf(reflect.TypeOf(netmesh.NetworkService{}))
+	spec.Required = []string{"name"}
+	constrainName(&spec, "name")
+
+	channel := spec.Properties["channels"].Items.Schema
+	channel.Required = []string{"name"}
+	constrainName(channel, "name")
+	constrainPayload(channel, "payload")
+	constrainVLAN(channel, "vlan")
+
+	expression := spec.Properties["labelSelector"].Properties["matchExpressions"].Items.Schema
+	expression.Required = []string{"key", "operator"}
+	constrainEnum(expression, "operator", selector.Operators)
+
+	exportTo := spec.Properties["exportTo"]
+	exportTo.Properties["namespaces"].Items.Schema.Pattern = v1.NamePattern
+	expression = exportTo.Properties["namespaceSelector"].Properties["matchExpressions"].Items.Schema
+	expression.Required = []string{"key", "operator"}
+	constrainEnum(expression, "operator", selector.Operators)
+
+	ref := spec.Properties["imports"].Items.Schema
+	ref.Required = []string{"namespace", "name"}
+	constrainName(ref, "namespace")
+	constrainName(ref, "name")
+
+	return specValidation(spec)
+}
+
+// networkServiceChannelValidation returns the validation of the
+// NetworkServiceChannel CRD.
+func networkServiceChannelValidation() *apiextv1beta1.CustomResourceValidation {
+	spec := schemaOf(reflect.TypeOf(netmesh.NetworkService_NetmeshChannel{}))
+	spec.Required = []string{"name", "payload"}
+	constrainName(&spec, "name")
+	constrainPayload(&spec, "payload")
+	constrainVLAN(&spec, "vlan")
+
+	return specValidation(spec)
+}
+
+// networkServiceEndpointValidation returns the validation of the
+// NetworkServiceEndpoint CRD.
+func networkServiceEndpointValidation() *apiextv1beta1.CustomResourceValidation {
+	spec := schemaOf(reflect.TypeOf(netmesh.NetworkServiceEndpoint{}))
+	spec.Required = []string{"name"}
+	constrainName(&spec, "name")
+
+	pod := spec.Properties["pod"]
+	pod.Required = []string{"name"}
+	spec.Properties["pod"] = pod
+	spec.Properties["mechanisms"].Items.Schema.Enum = enumOf(v1.LocalMechanisms)
+	spec.Properties["remoteMechanisms"].Items.Schema.Enum = enumOf(v1.RemoteMechanisms)
+
+	return specValidation(spec)
+}
+
+// networkServiceConnectionValidation returns the validation of the
+// NetworkServiceConnection CRD.
+func networkServiceConnectionValidation() *apiextv1beta1.CustomResourceValidation {
+	spec := schemaOf(reflect.TypeOf(netmesh.NetworkServiceConnection{}))
+	spec.Required = []string{"client", "networkService", "endpoint"}
+	constrainName(&spec, "networkService")
+	constrainName(&spec, "channel")
+	constrainEnum(&spec, "payload", v1.Payloads)
+
+	client := spec.Properties["client"]
+	client.Required = []string{"name"}
+	spec.Properties["client"] = client
+
+	endpoint := spec.Properties["endpoint"]
+	endpoint.Required = []string{"namespace", "name"}
+	constrainName(&endpoint, "namespace")
+	constrainName(&endpoint, "name")
+	spec.Properties["endpoint"] = endpoint
+
+	mechanism := spec.Properties["mechanism"]
+	mechanism.Required = []string{"type"}
+	constrainEnum(&mechanism, "type", v1.LocalMechanisms)
+	spec.Properties["mechanism"] = mechanism
+
+	remoteMechanism := spec.Properties["remoteMechanism"]
+	remoteMechanism.Required = []string{"type"}
+	constrainEnum(&remoteMechanism, "type", v1.RemoteMechanisms)
+	spec.Properties["remoteMechanism"] = remoteMechanism
+
+	return specValidation(spec)
+}
+
+// specValidation wraps the schema of a spec into the schema of the whole
+// object. With the status subresource enabled, the root of the schema may only
+// contain properties and required fields.
+func specValidation(spec apiextv1beta1.JSONSchemaProps) *apiextv1beta1.CustomResourceValidation {
+	return &apiextv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextv1beta1.JSONSchemaProps{
+			Properties: map[string]apiextv1beta1.JSONSchemaProps{
+				"spec": spec,
+			},
+			Required: []string{"spec"},
+		},
+	}
+}
+
+// constrainName restricts a string property to valid names.
+func constrainName(schema *apiextv1beta1.JSONSchemaProps, property string) {
+	prop := schema.Properties[property]
+	prop.Pattern = v1.NamePattern
+	schema.Properties[property] = prop
+}
+
+// constrainPayload restricts a string property to the known payloads.
+func constrainPayload(schema *apiextv1beta1.JSONSchemaProps, property string) {
+	constrainEnum(schema, property, v1.Payloads)
+}
+
+// constrainVLAN restricts an integer property to valid VLAN IDs, 0 standing
+// for no VLAN.
+func constrainVLAN(schema *apiextv1beta1.JSONSchemaProps, property string) {
+	prop := schema.Properties[property]
+	minimum, maximum := float64(0), float64(netmesh.MaxVLAN)
+	prop.Minimum = &minimum
+	prop.Maximum = &maximum
+	schema.Properties[property] = prop
+}
+
+// constrainEnum restricts a string property to the given values.
+func constrainEnum(schema *apiextv1beta1.JSONSchemaProps, property string, values []string) {
+	prop := schema.Properties[property]
+	prop.Enum = append(prop.Enum, enumOf(values)...)
+	schema.Properties[property] = prop
+}
+
+// enumOf returns the enumeration of the given string values.
+func enumOf(values []string) []apiextv1beta1.JSON {
+	enum := make([]apiextv1beta1.JSON, 0, len(values))
+	for _, value := range values {
+		raw, _ := json.Marshal(value)
+		enum = append(enum, apiextv1beta1.JSON{Raw: raw})
+	}
+	return enum
+}
+
+// schemaOf returns the schema of values of a Go type as encoded by
+// encoding/json, following the json tags of struct fields.
+func schemaOf(t reflect.Type) apiextv1beta1.JSONSchemaProps {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.String:
+		return apiextv1beta1.JSONSchemaProps{Type: "string"}
+	case reflect.Bool:
+		return apiextv1beta1.JSONSchemaProps{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return apiextv1beta1.JSONSchemaProps{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return apiextv1beta1.JSONSchemaProps{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		items := schemaOf(t.Elem())
+		return apiextv1beta1.JSONSchemaProps{
+			Type:  "array",
+			Items: &apiextv1beta1.JSONSchemaPropsOrArray{Schema: &items},
+		}
+	case reflect.Map:
+		values := schemaOf(t.Elem())
+		return apiextv1beta1.JSONSchemaProps{
+			Type:                 "object",
+			AdditionalProperties: &apiextv1beta1.JSONSchemaPropsOrBool{Allows: true, Schema: &values},
+		}
+	case reflect.Struct:
+		schema := apiextv1beta1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]apiextv1beta1.JSONSchemaProps{},
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" || field.PkgPath != "" {
+				// Not serialized, e.g. the XXX_ fields of protobuf messages
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			schema.Properties[name] = schemaOf(field.Type)
+		}
+		return schema
+	}
+	// Anything else is not constrained
+	return apiextv1beta1.JSONSchemaProps{}
+}
@@ -17,8 +17,12 @@
 package netmeshplugincrd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,59 +30,85 @@ import (
 	apiextcs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/workqueue"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/ligato/cn-infra/config"
 	"github.com/ligato/cn-infra/flavors/local"
 	"github.com/ligato/cn-infra/health/statuscheck"
-	"github.com/ligato/cn-infra/logging"
 	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v2"
 	client "github.com/ligato/networkservicemesh/pkg/client/clientset/versioned"
 	factory "github.com/ligato/networkservicemesh/pkg/client/informers/externalversions"
 )
 
+// DefaultShutdownTimeout is the time Close waits for the plugin to stop when
+// Plugin.ShutdownTimeout is not set.
+const DefaultShutdownTimeout = time.Second * 10
+
 // Plugin watches K8s resources and causes all changes to be reflected in the ETCD
 // data store.
 type Plugin struct {
 	Deps
 
-	pluginStopCh    chan struct{}
-	wg              sync.WaitGroup
 	k8sClientConfig *rest.Config
-	k8sClientset    *kubernetes.Clientset
-	apiclientset    *apiextcs.Clientset
+	k8sClientset    kubernetes.Interface
+	apiclientset    apiextcs.Interface
 	crdClient       client.Interface
 	StatusMonitor   statuscheck.StatusReader
 
+	// ShutdownTimeout bounds the time Close waits for in-flight reconciles
+	// to finish, it is read from the configuration when zero
+	ShutdownTimeout time.Duration
+	// Configuration of the plugin, see crd_config.go
+	config *Config
+
+	// Cancelling ctx stops all the informers, as well as control loops
+	// within the application. wg tracks the goroutines started by the plugin,
+	// Close waits for them to exit.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
 	// Used to signal hard errors while processing the queue
 	queueError chan bool
-	// These can be used to stop all the informers, as well as control loops
-	// within the application.
-	stopChNS  chan struct{}
-	stopChNSE chan struct{}
-	stopChNSC chan struct{}
-	// sharedFactory's are shared informer factorys used as a cache for
-	// items in the API server. They saves each informer listing and watch the
-	// same resources independently of each other, thus providing more up to
-	// date results with less 'effort'
-	sharedFactoryNS  factory.SharedInformerFactory
-	sharedFactoryNSE factory.SharedInformerFactory
-	sharedFactoryNSC factory.SharedInformerFactory
+	// Events of the plugin are recorded to the API server through the
+	// broadcaster, until eventWatch is stopped
+	eventBroadcaster record.EventBroadcaster
+	eventWatch       watch.Interface
+	// recorder records the events on the NSM objects, see crd_events.go
+	recorder record.EventRecorder
+	// sharedFactory is the shared informer factory used as a cache for items
+	// in the API server. It opens a single watch per resource, shared by the
+	// control loops and the queries of other plugins, see crd_api.go
+	sharedFactory factory.SharedInformerFactory
+	// namespaceInformer caches the Namespaces, whose labels select the
+	// namespaces services are exported to, see crd_export.go
+	namespaceInformer cache.SharedIndexInformer
+	// Controllers running the work queues of each resource, see crd_queue.go
+	nsController   *Controller
+	nscController  *Controller
+	nseController  *Controller
+	connController *Controller
+	// Cleanup hooks run when objects are deleted, see crd_delete.go and
+	// crd_finalize.go
+	deleteLock    sync.Mutex
+	deleteHooks   map[string][]DeleteHook
+	finalizeHooks map[string][]FinalizeHook
+	// Set to 1 while this instance is the leader, see crd_leader.go
+	leader         int32
+	electionTiming electionTiming
+	// Pools of addresses of the services, used by the leader, see
+	// crd_ipam.go
+	addresses addressPools
 }
 
-var (
-	// queue is a queue of resources to be processed. It performs exponential
-	// backoff rate limiting, with a minimum retry period of 5 seconds and a
-	// maximum of 1 minute.
-	queueNS  = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute))
-	queueNSC = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute))
-	queueNSE = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute))
-)
-
 // Deps defines dependencies of netmesh plugin.
 type Deps struct {
 	local.PluginInfraDeps
@@ -90,8 +120,14 @@ type Deps struct {
 // all reflectors.
 func (plugin *Plugin) Init() error {
 	var err error
-	plugin.Log.SetLevel(logging.DebugLevel)
-	plugin.pluginStopCh = make(chan struct{})
+	plugin.config, err = loadConfig(plugin)
+	if err != nil {
+		return err
+	}
+	plugin.Log.SetLevel(logLevels[strings.ToLower(plugin.config.LogLevel)])
+	if plugin.ShutdownTimeout == 0 {
+		plugin.ShutdownTimeout = plugin.config.ShutdownTimeout.Duration
+	}
 
 	kubeconfig := plugin.KubeConfig.GetConfigName()
 	plugin.Log.WithField("kubeconfig", kubeconfig).Info("Loading kubernetes client config")
@@ -105,160 +141,242 @@ func (plugin *Plugin) Init() error {
 		return fmt.Errorf("Failed to build kubernetes client: %s", err)
 	}
 
-	plugin.stopChNS = make(chan struct{})
-	plugin.stopChNSC = make(chan struct{})
-	plugin.stopChNSE = make(chan struct{})
+	// Create an instance of our own API client
+	plugin.crdClient, err = client.NewForConfig(plugin.k8sClientConfig)
+	if err != nil {
+		return fmt.Errorf("Failed to build CRD client: %s", err)
+	}
+
+	return plugin.setup()
+}
+
+// setup creates the state of the plugin once its configuration is loaded and
+// its clients are built. The plugin is started by start.
+func (plugin *Plugin) setup() error {
+	plugin.ctx, plugin.cancel = context.WithCancel(context.Background())
+
+	// We use shared informers from the informer factory, to save calls to the
+	// API as we grow our application and so state is consistent between our
+	// control loops. The resync period is configured, in case any
+	// create/replace/update/delete operations are missed when watching.
+	// The factory is created here so that the API of the plugin can be used
+	// by other plugins from their Init, the informers are started in
+	// AfterInit.
+	plugin.sharedFactory = newSharedFactory(plugin)
+	plugin.namespaceInformer = newNamespaceInformer(plugin)
+
+	plugin.eventBroadcaster = record.NewBroadcaster()
+	plugin.recorder = newEventRecorder(plugin)
 	plugin.queueError = make(chan bool, 1)
+	plugin.deleteHooks = make(map[string][]DeleteHook)
+	plugin.finalizeHooks = make(map[string][]FinalizeHook)
+
+	// Connections through a service or an endpoint being deleted are
+	// deleted before the service or the endpoint, see crd_connection.go
+	plugin.RegisterFinalizeHook(v1.NSMPlural, deleteConnectionsHook(plugin, serviceIndex))
+	plugin.RegisterFinalizeHook(v1.NSMEPPlural, deleteConnectionsHook(plugin, endpointIndex))
 
 	return nil
 }
 
-// Create the CRD resource, ignore error if it already exists
-func createCRD(plugin *Plugin, FullName, Group, Version, Plural, Name string) error {
-	crd := &apiextv1beta1.CustomResourceDefinition{
-		ObjectMeta: meta.ObjectMeta{Name: FullName},
+// newSharedFactory creates the informer factory of the plugin. A single
+// watched namespace is watched alone, otherwise all namespaces are watched
+// and the objects in other namespaces are ignored by the event handlers.
+func newSharedFactory(plugin *Plugin) factory.SharedInformerFactory {
+	resync := plugin.config.ResyncPeriod.Duration
+	if len(plugin.config.Namespaces) == 1 {
+		return factory.NewFilteredSharedInformerFactory(plugin.crdClient, resync, plugin.config.Namespaces[0], nil)
+	}
+	return factory.NewSharedInformerFactory(plugin.crdClient, resync)
+}
+
+// nsmVersions lists the API versions served for all NSM CRDs
+var nsmVersions = []string{v1.NSMGroupVersion, v2.NSMGroupVersion}
+
+// nsmStorageVersion is the API version NSM objects are persisted in
+const nsmStorageVersion = v1.NSMGroupVersion
+
+// crdVersions returns the versions of a CRD with the given storage version.
+// The storage version is listed first, as the API server requires the first
+// version of the list to match the version of the CRD.
+func crdVersions(storage string) []apiextv1beta1.CustomResourceDefinitionVersion {
+	versions := []apiextv1beta1.CustomResourceDefinitionVersion{
+		{Name: storage, Served: true, Storage: true},
+	}
+	for _, version := range nsmVersions {
+		if version != storage {
+			versions = append(versions, apiextv1beta1.CustomResourceDefinitionVersion{Name: version, Served: true})
+		}
+	}
+	return versions
+}
+
+// crdColumns converts the printer columns defined along with the API types to
+// their CRD representation.
+func crdColumns(columns []v1.PrinterColumn) []apiextv1beta1.CustomResourceColumnDefinition {
+	definitions := make([]apiextv1beta1.CustomResourceColumnDefinition, 0, len(columns))
+	for _, column := range columns {
+		definitions = append(definitions, apiextv1beta1.CustomResourceColumnDefinition{
+			Name:        column.Name,
+			Type:        column.Type,
+			JSONPath:    column.JSONPath,
+			Description: column.Description,
+			Priority:    column.Priority,
+		})
+	}
+	return definitions
+}
+
+// crdDefinition describes a CRD created by the plugin.
+type crdDefinition struct {
+	fullName   string
+	group      string
+	versions   []apiextv1beta1.CustomResourceDefinitionVersion
+	plural     string
+	kind       string
+	shortName  string
+	columns    []v1.PrinterColumn
+	validation *apiextv1beta1.CustomResourceValidation
+}
+
+// crd returns the CRD resource of the definition.
+func (definition *crdDefinition) crd() *apiextv1beta1.CustomResourceDefinition {
+	return &apiextv1beta1.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{Name: definition.fullName},
 		Spec: apiextv1beta1.CustomResourceDefinitionSpec{
-			Group:   Group,
-			Version: Version,
-			Scope:   apiextv1beta1.NamespaceScoped,
+			Group:    definition.group,
+			Version:  definition.versions[0].Name,
+			Versions: definition.versions,
+			Scope:    apiextv1beta1.NamespaceScoped,
 			Names: apiextv1beta1.CustomResourceDefinitionNames{
-				Plural: Plural,
-				Kind:   Name,
+				Plural:     definition.plural,
+				Kind:       definition.kind,
+				ShortNames: []string{definition.shortName},
+				// kubectl get nsm lists the resources of all NSM CRDs
+				Categories: []string{v1.NSMCategory},
+			},
+			AdditionalPrinterColumns: crdColumns(definition.columns),
+			Validation:               definition.validation,
+			// The status is a subresource, so that status writes by the
+			// plugin neither bump the generation nor race with spec updates
+			Subresources: &apiextv1beta1.CustomResourceSubresources{
+				Status: &apiextv1beta1.CustomResourceSubresourceStatus{},
 			},
 		},
 	}
+}
+
+// Create the CRD resource, update its spec if it already exists
+func createCRD(plugin *Plugin, definition *crdDefinition) error {
+	crd := definition.crd()
+
+	if plugin.config.CRDsPreinstalled {
+		return checkCRD(plugin, crd)
+	}
 
 	_, cserr := plugin.apiclientset.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
 	if cserr != nil && apierrors.IsAlreadyExists(cserr) {
-		plugin.Log.Infof("Created CRD %s succesfully, though it already existed", Name)
-		return nil
+		return updateCRD(plugin, crd)
 	} else if cserr != nil {
-		plugin.Log.Infof("Error creating CRD %s: %s", Name, cserr)
+		plugin.Log.Infof("Error creating CRD %s: %s", definition.kind, cserr)
 	} else {
-		plugin.Log.Infof("Created CRD %s succesfully", Name)
+		plugin.Log.Infof("Created CRD %s succesfully", definition.kind)
 	}
 
 	return cserr
 }
 
-func informerNetworkServices(plugin *Plugin) {
-	// We use a shared informer from the informer factory, to save calls to the
-	// API as we grow our application and so state is consistent between our
-	// control loops. We set a resync period of 30 seconds, in case any
-	// create/replace/update/delete operations are missed when watching
-	plugin.sharedFactoryNS = factory.NewSharedInformerFactory(plugin.crdClient, time.Second*30)
-
-	informer := plugin.sharedFactoryNS.Networkservice().V1().NetworkServices().Informer()
-	// We add a new event handler, watching for changes to API resources.
-	informer.AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc: networkserviceEnqueue,
-			UpdateFunc: func(old, cur interface{}) {
-				if !reflect.DeepEqual(old, cur) {
-					networkserviceEnqueue(cur)
-				}
-			},
-			DeleteFunc: networkserviceEnqueue,
+// pluginCRDSpec returns the fields of a CRD spec which are set by the plugin.
+// The fields defaulted by the API server, such as the singular name and the
+// list kind, are left out.
+func pluginCRDSpec(spec *apiextv1beta1.CustomResourceDefinitionSpec) apiextv1beta1.CustomResourceDefinitionSpec {
+	return apiextv1beta1.CustomResourceDefinitionSpec{
+		Group:    spec.Group,
+		Version:  spec.Version,
+		Versions: spec.Versions,
+		Scope:    spec.Scope,
+		Names: apiextv1beta1.CustomResourceDefinitionNames{
+			Plural:     spec.Names.Plural,
+			Kind:       spec.Names.Kind,
+			ShortNames: spec.Names.ShortNames,
+			Categories: spec.Names.Categories,
 		},
-	)
-
-	// Start the informer. This will cause it to begin receiving updates from
-	// the configured API server and firing event handlers in response.
-	plugin.sharedFactoryNS.Start(plugin.stopChNS)
-	plugin.Log.Info("Started NetworkService informer factory.")
-
-	// Wait for the informer cache to finish performing it's initial sync of
-	// resources
-	if !cache.WaitForCacheSync(plugin.stopChNS, informer.HasSynced) {
-		plugin.Log.Error("Error waiting for informer cache to sync")
+		AdditionalPrinterColumns: spec.AdditionalPrinterColumns,
+		Validation:               spec.Validation,
+		Subresources:             spec.Subresources,
 	}
-
-	plugin.Log.Info("NetworkService Informer is ready")
-
-	// Read forever from the work queue
-	networkserviceWork(plugin)
 }
 
-func informerNetworkServiceChannels(plugin *Plugin) {
-	// We use a shared informer from the informer factory, to save calls to the
-	// API as we grow our application and so state is consistent between our
-	// control loops. We set a resync period of 30 seconds, in case any
-	// create/replace/update/delete operations are missed when watching
-	plugin.sharedFactoryNSC = factory.NewSharedInformerFactory(plugin.crdClient, time.Second*30)
-
-	informer := plugin.sharedFactoryNSC.Networkservice().V1().NetworkServiceChannels().Informer()
-	// we add a new event handler, watching for changes to API resources.
-	informer.AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc: networkservicechannelEnqueue,
-			UpdateFunc: func(old, cur interface{}) {
-				if !reflect.DeepEqual(old, cur) {
-					networkservicechannelEnqueue(cur)
-				}
-			},
-			DeleteFunc: networkservicechannelEnqueue,
-		},
-	)
-
-	// Start the informer. This will cause it to begin receiving updates from
-	// the configured API server and firing event handlers in response.
-	plugin.sharedFactoryNSC.Start(plugin.stopChNSC)
-	plugin.Log.Info("Started NetworkServiceChannel informer factory.")
+// crdSpecMatches returns whether the fields set by the plugin are the same in
+// both specs. The fields are compared in their serialized form, as the specs
+// read from the API server have nil and empty lists and maps mixed up.
+func crdSpecMatches(existing, expected *apiextv1beta1.CustomResourceDefinitionSpec) bool {
+	existingJSON, err := json.Marshal(pluginCRDSpec(existing))
+	if err != nil {
+		return false
+	}
+	expectedJSON, err := json.Marshal(pluginCRDSpec(expected))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(existingJSON, expectedJSON)
+}
 
-	// Wait for the informer cache to finish performing it's initial sync of
-	// resources
-	if !cache.WaitForCacheSync(plugin.stopChNSC, informer.HasSynced) {
-		plugin.Log.Errorf("Error waiting for informer cache to sync")
+// updateCRD brings the spec of an existing CRD resource in line with the one
+// the plugin would have created, e.g. to enable subresources on CRDs created by
+// older versions. The group, scope, plural and kind of a CRD cannot change, the
+// fields defaulted by the API server are left untouched.
+func updateCRD(plugin *Plugin, crd *apiextv1beta1.CustomResourceDefinition) error {
+	existing, err := plugin.apiclientset.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.Name, meta.GetOptions{})
+	if err != nil {
+		plugin.Log.Infof("Error getting existing CRD %s: %s", crd.Spec.Names.Kind, err)
+		return err
+	}
+	if crdSpecMatches(&existing.Spec, &crd.Spec) {
+		plugin.Log.Infof("Created CRD %s succesfully, though it already existed", crd.Spec.Names.Kind)
+		return nil
 	}
 
-	plugin.Log.Info("NetworkServiceChannel Informer is ready")
+	existing.Spec.Version = crd.Spec.Version
+	existing.Spec.Versions = crd.Spec.Versions
+	existing.Spec.Names.ShortNames = crd.Spec.Names.ShortNames
+	existing.Spec.Names.Categories = crd.Spec.Names.Categories
+	existing.Spec.AdditionalPrinterColumns = crd.Spec.AdditionalPrinterColumns
+	existing.Spec.Validation = crd.Spec.Validation
+	existing.Spec.Subresources = crd.Spec.Subresources
+	if _, err = plugin.apiclientset.ApiextensionsV1beta1().CustomResourceDefinitions().Update(existing); err != nil {
+		plugin.Log.Infof("Error updating CRD %s: %s", crd.Spec.Names.Kind, err)
+		return err
+	}
+	plugin.Log.Infof("Updated existing CRD %s succesfully", crd.Spec.Names.Kind)
 
-	// Read forever from the work queue
-	networkservicechannelWork(plugin)
+	return nil
 }
 
-func informerNetworkServiceEndpoints(plugin *Plugin) {
-	// We use a shared informer from the informer factory, to save calls to the
-	// API as we grow our application and so state is consistent between our
-	// control loops. We set a resync period of 30 seconds, in case any
-	// create/replace/update/delete operations are missed when watching
-	plugin.sharedFactoryNSE = factory.NewSharedInformerFactory(plugin.crdClient, time.Second*30)
-
-	informer := plugin.sharedFactoryNSE.Networkservice().V1().NetworkServiceEndpoints().Informer()
-	// we add a new event handler, watching for changes to API resources.
-	informer.AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc: networkserviceendpointEnqueue,
-			UpdateFunc: func(old, cur interface{}) {
-				if !reflect.DeepEqual(old, cur) {
-					networkserviceendpointEnqueue(cur)
-				}
-			},
-			DeleteFunc: networkserviceendpointEnqueue,
-		},
-	)
-
-	// Start the informer. This will cause it to begin receiving updates from
-	// the configured API server and firing event handlers in response.
-	plugin.sharedFactoryNSE.Start(plugin.stopChNSE)
-	plugin.Log.Info("Started NetworkServiceEndpoints informer factory.")
-
-	// Wait for the informer cache to finish performing it's initial sync of
-	// resources
-	if !cache.WaitForCacheSync(plugin.stopChNSE, informer.HasSynced) {
-		plugin.Log.Errorf("Error waiting for informer cache to sync")
+// checkCRD verifies that a CRD installed outside of the plugin exists. A spec
+// differing from the one the plugin would have created is only reported, as
+// the installed CRD may be newer than the plugin.
+func checkCRD(plugin *Plugin, crd *apiextv1beta1.CustomResourceDefinition) error {
+	existing, err := plugin.apiclientset.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.Name, meta.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("CRD %s is not installed", crd.Name)
 	}
+	if err != nil {
+		plugin.Log.Infof("Error getting preinstalled CRD %s: %s", crd.Spec.Names.Kind, err)
+		return err
+	}
+	if !crdSpecMatches(&existing.Spec, &crd.Spec) {
+		plugin.Log.Warnf("Preinstalled CRD %s differs from the CRD expected by the plugin", crd.Spec.Names.Kind)
+		return nil
+	}
+	plugin.Log.Infof("Found preinstalled CRD %s", crd.Spec.Names.Kind)
 
-	plugin.Log.Info("NetworkServiceEndpoint Informer is ready")
-
-	// Read forever from the work queue
-	networkserviceendpointWork(plugin)
+	return nil
 }
 
 // AfterInit This will create all of the CRDs for NetworkServiceMesh.
 func (plugin *Plugin) AfterInit() error {
 	var err error
-	var crdname string
 
 	// Create clientset and create our CRD, this only needs to run once
 	plugin.apiclientset, err = apiextcs.NewForConfig(plugin.k8sClientConfig)
@@ -266,69 +384,218 @@ func (plugin *Plugin) AfterInit() error {
 		panic(err.Error())
 	}
 
-	// Create an instance of our own API client
-	plugin.crdClient, err = client.NewForConfig(plugin.k8sClientConfig)
-	if err != nil {
-		plugin.Log.Errorf("Error creating CRD client: %s", err.Error())
-		panic(err.Error())
-	}
-
-	crdname = reflect.TypeOf(v1.NetworkServiceEndpoint{}).Name()
-	err = createCRD(plugin, v1.FullNSMEPName,
-		v1.NSMGroup,
-		v1.NSMGroupVersion,
-		v1.NSMEPPlural,
-		crdname)
+	err = createCRD(plugin, &crdDefinition{
+		fullName:   v1.FullNSMEPName,
+		group:      v1.NSMGroup,
+		versions:   crdVersions(nsmStorageVersion),
+		plural:     v1.NSMEPPlural,
+		kind:       reflect.TypeOf(v1.NetworkServiceEndpoint{}).Name(),
+		shortName:  v1.NSMEPShortName,
+		columns:    v1.NetworkServiceEndpointColumns,
+		validation: networkServiceEndpointValidation(),
+	})
 
 	if err != nil {
 		plugin.Log.Error("Error initializing NetworkServiceEndpoint CRD")
 		return err
 	}
 
-	crdname = reflect.TypeOf(v1.NetworkServiceChannel{}).Name()
-	err = createCRD(plugin, v1.FullNSMChannelName,
-		v1.NSMGroup,
-		v1.NSMGroupVersion,
-		v1.NSMChannelPlural,
-		crdname)
+	err = createCRD(plugin, &crdDefinition{
+		fullName:   v1.FullNSMChannelName,
+		group:      v1.NSMGroup,
+		versions:   crdVersions(nsmStorageVersion),
+		plural:     v1.NSMChannelPlural,
+		kind:       reflect.TypeOf(v1.NetworkServiceChannel{}).Name(),
+		shortName:  v1.NSMChannelShortName,
+		columns:    v1.NetworkServiceChannelColumns,
+		validation: networkServiceChannelValidation(),
+	})
 
 	if err != nil {
 		plugin.Log.Error("Error initializing NetworkServiceChannel CRD")
 		return err
 	}
 
-	crdname = reflect.TypeOf(v1.NetworkService{}).Name()
-	err = createCRD(plugin, v1.FullNSMName,
-		v1.NSMGroup,
-		v1.NSMGroupVersion,
-		v1.NSMPlural,
-		crdname)
+	err = createCRD(plugin, &crdDefinition{
+		fullName:   v1.FullNSMName,
+		group:      v1.NSMGroup,
+		versions:   crdVersions(nsmStorageVersion),
+		plural:     v1.NSMPlural,
+		kind:       reflect.TypeOf(v1.NetworkService{}).Name(),
+		shortName:  v1.NSMShortName,
+		columns:    v1.NetworkServiceColumns,
+		validation: networkServiceValidation(),
+	})
 
 	if err != nil {
 		plugin.Log.Error("Error initializing NetworkService CRD")
 		return err
 	}
 
-	go informerNetworkServices(plugin)
-	go informerNetworkServiceChannels(plugin)
-	go informerNetworkServiceEndpoints(plugin)
-	go handleQueueErrors(plugin)
+	// Connections are only served in the storage version, they are not
+	// part of the older versions of the API
+	err = createCRD(plugin, &crdDefinition{
+		fullName:   v1.FullNSMConnectionName,
+		group:      v1.NSMGroup,
+		versions:   []apiextv1beta1.CustomResourceDefinitionVersion{{Name: v1.NSMGroupVersion, Served: true, Storage: true}},
+		plural:     v1.NSMConnectionPlural,
+		kind:       reflect.TypeOf(v1.NetworkServiceConnection{}).Name(),
+		shortName:  v1.NSMConnectionShortName,
+		columns:    v1.NetworkServiceConnectionColumns,
+		validation: networkServiceConnectionValidation(),
+	})
+
+	if err != nil {
+		plugin.Log.Error("Error initializing NetworkServiceConnection CRD")
+		return err
+	}
+
+	return plugin.start()
+}
+
+// start creates the control loops and starts them along with the informers.
+// The goroutines run until the context of the plugin is cancelled.
+func (plugin *Plugin) start() error {
+	// The informers and controllers are created before any informer is
+	// started, as the control loops read from each other's caches. The
+	// indexes have to be added before the informers are started as well.
+	if err := addIndexers(plugin); err != nil {
+		plugin.Log.Errorf("Error adding indexers: %s", err)
+		return err
+	}
+	plugin.nsController = newNetworkServiceController(plugin)
+	plugin.nscController = newNetworkServiceChannelController(plugin)
+	plugin.nseController = newNetworkServiceEndpointController(plugin)
+	plugin.connController = newNetworkServiceConnectionController(plugin)
+	addDependencyHandlers(plugin)
+	addExportHandlers(plugin)
+
+	// Only the leader writes the cluster-wide state, the election starts
+	// once the controllers exist as they are resynced on leadership changes
+	stopCh := plugin.ctx.Done()
+	if plugin.electionTiming == (electionTiming{}) {
+		plugin.electionTiming = defaultElectionTiming
+	}
+	elector, err := newLeaderElector(plugin, stopCh)
+	if err != nil {
+		plugin.Log.Errorf("Error initializing leader election: %s", err)
+		return err
+	}
+
+	// Events are sent to the namespace of the object they are about
+	plugin.eventWatch = plugin.eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: plugin.k8sClientset.CoreV1().Events("")})
+
+	// Start the informers. This will cause them to begin receiving updates
+	// from the configured API server and firing event handlers in response.
+	for _, informer := range plugin.informers() {
+		plugin.spawn(func(informer cache.SharedIndexInformer) func() {
+			return func() { informer.Run(stopCh) }
+		}(informer))
+	}
+	plugin.Log.Info("Started NSM informers.")
+
+	// Read from the work queues until the plugin is stopped
+	plugin.spawn(func() { plugin.nsController.Run(stopCh) })
+	plugin.spawn(func() { plugin.nscController.Run(stopCh) })
+	plugin.spawn(func() { plugin.nseController.Run(stopCh) })
+	plugin.spawn(func() { plugin.connController.Run(stopCh) })
+	plugin.spawn(func() { handleQueueErrors(plugin) })
+	plugin.spawn(func() { runLeaderElection(elector, stopCh) })
+	plugin.spawn(func() { runOrphanSweeper(plugin, stopCh) })
 
 	return nil
 }
 
+// informers returns the informers of the plugin. They are run by the plugin
+// rather than by the factory, so that Close can wait for them to stop.
+func (plugin *Plugin) informers() []cache.SharedIndexInformer {
+	informers := plugin.sharedFactory.Networkservice().V1()
+	return []cache.SharedIndexInformer{
+		informers.NetworkServices().Informer(),
+		informers.NetworkServiceChannels().Informer(),
+		informers.NetworkServiceEndpoints().Informer(),
+		informers.NetworkServiceConnections().Informer(),
+		plugin.namespaceInformer,
+	}
+}
+
+// spawn runs f in a goroutine tracked by the wait group of the plugin.
+func (plugin *Plugin) spawn(f func()) {
+	plugin.wg.Add(1)
+	go func() {
+		defer plugin.wg.Done()
+		f()
+	}()
+}
+
+// queueFailed is called by the controllers with unrecoverable errors, it
+// raises the flag so the plugin catches this and shuts down.
+func (plugin *Plugin) queueFailed(err error) {
+	plugin.Log.Error(err.Error())
+	select {
+	case plugin.queueError <- true:
+	default:
+		// The plugin is already shutting down
+	}
+}
+
 // handleQueueErrors monitors the queueError channel for errors from the
-// dequeueing functions and closes the plugin down if one is received.
+// dequeueing functions and stops the plugin if one is received.
 func handleQueueErrors(plugin *Plugin) {
-	<-plugin.queueError
-	plugin.Log.Error("Error processing queues, shutting plugin down")
-
-	plugin.Close()
+	select {
+	case <-plugin.queueError:
+		plugin.Log.Error("Error processing queues, shutting plugin down")
+		plugin.cancel()
+	case <-plugin.ctx.Done():
+	}
 }
 
-// Close stops all reflectors.
+// Close stops the informers and the control loops. Items being reconciled
+// are given ShutdownTimeout to finish, Close returns once all the goroutines
+// of the plugin have exited or with an error once the timeout expired. The
+// leader elector cannot be interrupted and takes up to its renew deadline to
+// return, Close waits for it even when ShutdownTimeout is shorter.
 func (plugin *Plugin) Close() error {
-	close(plugin.pluginStopCh)
-	plugin.wg.Wait()
+	plugin.closeOnce.Do(func() {
+		plugin.closeErr = plugin.shutdown()
+	})
+	return plugin.closeErr
+}
+
+// shutdown cancels the context of the plugin and waits for its goroutines.
+func (plugin *Plugin) shutdown() error {
+	if plugin.cancel == nil {
+		// Init failed before anything was started
+		return nil
+	}
+	plugin.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		plugin.wg.Wait()
+		close(done)
+	}()
+	timeout := plugin.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	if election := plugin.electionTiming.stopTimeout(); election > timeout {
+		timeout = election
+	}
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for the CRD plugin to stop", timeout)
+	}
+
+	if plugin.eventWatch != nil {
+		plugin.eventWatch.Stop()
+	}
+	if broadcaster, ok := plugin.eventBroadcaster.(interface{ Shutdown() }); ok {
+		// Flushes the events recorded so far
+		broadcaster.Shutdown()
+	}
+	plugin.Log.Info("CRD plugin stopped")
+
 	return nil
 }
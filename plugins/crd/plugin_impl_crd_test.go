@@ -0,0 +1,267 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmeshplugincrd
+
+import (
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ligato/cn-infra/logging"
+	"github.com/ligato/cn-infra/logging/logrus"
+
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/client/clientset/versioned/fake"
+)
+
+// testElectionTiming shortens the leader election so that the elector of a
+// test plugin stops within a fraction of a second.
+var testElectionTiming = electionTiming{
+	leaseDuration: time.Millisecond * 300,
+	renewDeadline: time.Millisecond * 200,
+	retryPeriod:   time.Millisecond * 50,
+}
+
+// newTestPlugin creates a plugin on fake clientsets holding the given
+// objects. The plugin is set up but not started.
+func newTestPlugin(t *testing.T, shutdownTimeout time.Duration, objects ...*v1.NetworkService) *Plugin {
+	crdClient := fake.NewSimpleClientset()
+	for _, ns := range objects {
+		if _, err := crdClient.NetworkserviceV1().NetworkServices(ns.Namespace).Create(ns); err != nil {
+			t.Fatalf("error creating NetworkService '%s/%s': %s", ns.Namespace, ns.Name, err)
+		}
+	}
+
+	plugin := &Plugin{
+		ShutdownTimeout: shutdownTimeout,
+		config:          DefaultConfig(),
+		k8sClientset:    k8sfake.NewSimpleClientset(),
+		crdClient:       crdClient,
+		electionTiming:  testElectionTiming,
+	}
+	plugin.Log = logging.ForPlugin("netmeshplugincrd-test", logrus.NewLogRegistry())
+	plugin.Log.SetLevel(logging.ErrorLevel)
+	if err := plugin.setup(); err != nil {
+		t.Fatalf("error setting up the plugin: %s", err)
+	}
+	return plugin
+}
+
+// startTestPlugin starts the plugin and waits until its caches are synced and
+// it leads the election.
+func startTestPlugin(t *testing.T, plugin *Plugin) {
+	if err := plugin.start(); err != nil {
+		t.Fatalf("error starting the plugin: %s", err)
+	}
+	eventually(t, "the plugin to sync and lead", func() bool {
+		return plugin.HasSynced() && plugin.IsLeader()
+	})
+}
+
+// closeTimed closes the plugin and returns the error of Close along with the
+// time it took.
+func closeTimed(plugin *Plugin) (time.Duration, error) {
+	start := time.Now()
+	err := plugin.Close()
+	return time.Since(start), err
+}
+
+// blockDeletes registers a delete hook on NetworkServices which blocks until
+// release is closed. started is closed once the hook is entered, done once it
+// returned.
+func blockDeletes(plugin *Plugin, release <-chan struct{}) (started, done <-chan struct{}) {
+	startedCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	plugin.RegisterDeleteHook(v1.NSMPlural, func(namespace, name string, obj interface{}) error {
+		close(startedCh)
+		<-release
+		close(doneCh)
+		return nil
+	})
+	return startedCh, doneCh
+}
+
+// deleteTestService deletes a NetworkService and waits for its delete hooks
+// to be entered.
+func deleteTestService(t *testing.T, plugin *Plugin, ns *v1.NetworkService, started <-chan struct{}) {
+	err := plugin.crdClient.NetworkserviceV1().NetworkServices(ns.Namespace).Delete(ns.Name, &meta.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("error deleting NetworkService '%s/%s': %s", ns.Namespace, ns.Name, err)
+	}
+	select {
+	case <-started:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("timed out waiting for the delete of '%s/%s' to be reconciled", ns.Namespace, ns.Name)
+	}
+}
+
+func testService() *v1.NetworkService {
+	return &v1.NetworkService{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "gold-network"},
+	}
+}
+
+func TestCloseStopsPlugin(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	plugin := newTestPlugin(t, time.Second)
+	startTestPlugin(t, plugin)
+
+	elapsed, err := closeTimed(plugin)
+	if err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	if bound := plugin.electionTiming.stopTimeout(); elapsed > bound {
+		t.Errorf("Close took %s, expected at most %s", elapsed, bound)
+	}
+
+	for _, c := range []*Controller{plugin.nsController, plugin.nscController, plugin.nseController, plugin.connController} {
+		if !c.queue.ShuttingDown() {
+			t.Errorf("work queue of %s has not been shut down", c.Name)
+		}
+	}
+	if plugin.IsLeader() {
+		t.Error("plugin still leads the election once closed")
+	}
+	if err = plugin.Close(); err != nil {
+		t.Errorf("second Close failed: %s", err)
+	}
+
+	// The callbacks of the elector run in goroutines of their own, which
+	// exit right after Close returned
+	eventually(t, "the goroutines of the plugin to exit", func() bool {
+		return runtime.NumGoroutine() <= goroutines
+	})
+}
+
+func TestCloseDrainsInFlightReconciles(t *testing.T) {
+	ns := testService()
+	plugin := newTestPlugin(t, time.Second*2, ns)
+	release := make(chan struct{})
+	started, done := blockDeletes(plugin, release)
+	startTestPlugin(t, plugin)
+	deleteTestService(t, plugin, ns, started)
+
+	// The reconcile finishes while Close waits for it
+	time.AfterFunc(time.Millisecond*300, func() { close(release) })
+	elapsed, err := closeTimed(plugin)
+	if err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	select {
+	case <-done:
+	default:
+		t.Fatal("Close returned before the in-flight reconcile finished")
+	}
+	if elapsed >= plugin.ShutdownTimeout {
+		t.Errorf("Close took %s, expected less than the shutdown timeout %s", elapsed, plugin.ShutdownTimeout)
+	}
+}
+
+func TestCloseTimesOut(t *testing.T) {
+	ns := testService()
+	plugin := newTestPlugin(t, time.Second, ns)
+	release := make(chan struct{})
+	defer close(release)
+	started, done := blockDeletes(plugin, release)
+	startTestPlugin(t, plugin)
+	deleteTestService(t, plugin, ns, started)
+
+	elapsed, err := closeTimed(plugin)
+	if err == nil {
+		t.Fatal("Close succeeded while a reconcile was blocked")
+	}
+	if elapsed < plugin.ShutdownTimeout || elapsed > plugin.ShutdownTimeout+time.Second {
+		t.Errorf("Close took %s, expected the shutdown timeout %s", elapsed, plugin.ShutdownTimeout)
+	}
+	select {
+	case <-done:
+		t.Error("blocked reconcile finished")
+	default:
+	}
+}
+
+// installedCRD returns the CRD of a definition as read back from the API
+// server, with the fields the server defaults filled in.
+func installedCRD(t *testing.T, definition *crdDefinition) *apiextv1beta1.CustomResourceDefinition {
+	data, err := json.Marshal(definition.crd())
+	if err != nil {
+		t.Fatal(err)
+	}
+	installed := &apiextv1beta1.CustomResourceDefinition{}
+	if err := json.Unmarshal(data, installed); err != nil {
+		t.Fatal(err)
+	}
+	installed.Spec.Names.Singular = strings.ToLower(installed.Spec.Names.Kind)
+	installed.Spec.Names.ListKind = installed.Spec.Names.Kind + "List"
+	installed.ResourceVersion = "42"
+	return installed
+}
+
+func TestCRDSpecMatches(t *testing.T) {
+	definition := &crdDefinition{
+		fullName:   v1.FullNSMEPName,
+		group:      v1.NSMGroup,
+		versions:   crdVersions(nsmStorageVersion),
+		plural:     v1.NSMEPPlural,
+		kind:       "NetworkServiceEndpoint",
+		shortName:  v1.NSMEPShortName,
+		columns:    v1.NetworkServiceEndpointColumns,
+		validation: networkServiceEndpointValidation(),
+	}
+	tests := []struct {
+		name    string
+		change  func(spec *apiextv1beta1.CustomResourceDefinitionSpec)
+		matches bool
+	}{
+		{"defaulted by the API server", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {}, true},
+		{"no status subresource", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.Subresources = nil
+		}, false},
+		{"no validation", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.Validation = nil
+		}, false},
+		{"other storage version", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.Versions[0].Storage, spec.Versions[1].Storage = false, true
+		}, false},
+		{"no short names", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.Names.ShortNames = nil
+		}, false},
+		{"no categories", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.Names.Categories = nil
+		}, false},
+		{"default printer columns", func(spec *apiextv1beta1.CustomResourceDefinitionSpec) {
+			spec.AdditionalPrinterColumns = []apiextv1beta1.CustomResourceColumnDefinition{
+				{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+			}
+		}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			installed := installedCRD(t, definition)
+			test.change(&installed.Spec)
+			if matches := crdSpecMatches(&installed.Spec, &definition.crd().Spec); matches != test.matches {
+				t.Errorf("expected the specs to match: %t, got %t", test.matches, matches)
+			}
+		})
+	}
+}
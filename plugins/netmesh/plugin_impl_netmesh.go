@@ -28,7 +28,10 @@ import (
 	"github.com/ligato/cn-infra/flavors/local"
 	"github.com/ligato/cn-infra/health/statuscheck"
 	"github.com/ligato/cn-infra/logging"
+	"github.com/ligato/cn-infra/rpc/grpc"
 	"github.com/ligato/networkservicemesh/nsmdp"
+	"github.com/ligato/networkservicemesh/pkg/nsm/apis/pod2nsm"
+	"github.com/ligato/networkservicemesh/plugins/crd"
 )
 
 // Global NSMDevicePlugin used across plugin methods (AfterInit and Close)
@@ -53,6 +56,10 @@ type Deps struct {
 	local.PluginInfraDeps
 	// Kubeconfig with k8s cluster address and access credentials to use.
 	KubeConfig config.PluginConfig
+	// CRD gives access to the cached NSM objects.
+	CRD netmeshplugincrd.API
+	// GRPC serves the pod2nsm API, if enabled.
+	GRPC grpc.Server
 }
 
 // Init builds K8s client-set based on the supplied kubeconfig and initializes
@@ -74,6 +81,12 @@ func (plugin *Plugin) Init() error {
 		return fmt.Errorf("failed to build kubernetes client: %s", err)
 	}
 
+	// The services have to be registered before the GRPC plugin starts
+	// serving in its AfterInit
+	if plugin.GRPC != nil && !plugin.GRPC.IsDisabled() {
+		pod2nsm.RegisterNetworkServicesServer(plugin.GRPC.GetServer(), &pod2nsmServer{plugin: plugin})
+	}
+
 	return nil
 }
 
@@ -0,0 +1,250 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmesh
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ligato/networkservicemesh/netmesh/model/netmesh"
+	"github.com/ligato/networkservicemesh/pkg/apis/networkservicemesh.io/v1"
+	"github.com/ligato/networkservicemesh/pkg/nsm/apis/pod2nsm"
+	"github.com/ligato/networkservicemesh/plugins/crd"
+)
+
+// pod2nsmServer implements the pod2nsm API on top of the caches of the CRD
+// plugin. Services, endpoints and connections are addressed by their UUID.
+type pod2nsmServer struct {
+	plugin *Plugin
+}
+
+// Compile time check that the server implements the API
+var _ pod2nsm.NetworkServicesServer = &pod2nsmServer{}
+
+// DiscoverService returns the UUIDs of the services carrying all the labels
+// of the request.
+func (s *pod2nsmServer) DiscoverService(ctx context.Context, req *pod2nsm.DiscoverServiceRequest) (*pod2nsm.ServiceDiscoveryResponse, error) {
+	services, err := s.plugin.CRD.ListNetworkServices("")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing services: %s", err)
+	}
+
+	sel := labels.SelectorFromSet(labels.Set(req.Labels))
+	response := &pod2nsm.ServiceDiscoveryResponse{}
+	for _, ns := range services {
+		// Services are only discoverable once the CRD plugin assigned
+		// their UUID
+		if ns.Spec.Uuid != "" && sel.Matches(labels.Set(ns.Labels)) {
+			response.ServiceIds = append(response.ServiceIds, ns.Spec.Uuid)
+		}
+	}
+	return response, nil
+}
+
+// GetService returns the service with the UUID of the request, along with
+// the UUIDs of the endpoints it selects.
+func (s *pod2nsmServer) GetService(ctx context.Context, req *pod2nsm.GetServiceRequest) (*pod2nsm.GetServiceResponse, error) {
+	ns, err := s.plugin.CRD.GetNetworkServiceByUUID(req.ServiceId)
+	if err != nil {
+		return nil, lookupError("service", req.ServiceId, err)
+	}
+	endpoints, err := s.plugin.CRD.ListNetworkServiceEndpointsSelectedBy(ns)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "error selecting endpoints of service %s: %s", req.ServiceId, err)
+	}
+
+	response := &pod2nsm.GetServiceResponse{
+		ServiceId: ns.Spec.Uuid,
+		Name:      ns.Name,
+		Namespace: ns.Namespace,
+		Labels:    ns.Labels,
+	}
+	for _, nse := range endpoints {
+		if nse.Spec.Uuid != "" {
+			response.EndpointIds = append(response.EndpointIds, nse.Spec.Uuid)
+		}
+	}
+	return response, nil
+}
+
+// GetEndpoint returns the endpoint with the UUID of the request.
+func (s *pod2nsmServer) GetEndpoint(ctx context.Context, req *pod2nsm.GetEndpointRequest) (*pod2nsm.GetEndpointResponse, error) {
+	nse, err := s.plugin.CRD.GetNetworkServiceEndpointByUUID(req.EndpointId)
+	if err != nil {
+		return nil, lookupError("endpoint", req.EndpointId, err)
+	}
+	return endpointResponse(nse), nil
+}
+
+// PublishService publishes the pod of the request as an endpoint, owned by
+// the pod, and returns the UUID of the endpoint.
+func (s *pod2nsmServer) PublishService(ctx context.Context, req *pod2nsm.PublishServiceRequest) (*pod2nsm.PublishServiceResponse, error) {
+	if req.PodNamespace == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the pod are required")
+	}
+	spec := &netmesh.NetworkServiceEndpoint{
+		Name:             req.Name,
+		Socket:           req.Socket,
+		Labels:           req.Labels,
+		Mechanisms:       req.Mechanisms,
+		Capacity:         req.Capacity,
+		RemoteMechanisms: req.RemoteMechanisms,
+	}
+	nse, err := s.plugin.CRD.PublishEndpoint(req.PodNamespace, req.PodName, spec, req.Labels)
+	if err != nil {
+		return nil, apiError("publishing endpoint of pod "+req.PodNamespace+"/"+req.PodName, err)
+	}
+	return &pod2nsm.PublishServiceResponse{EndpointId: nse.Spec.Uuid}, nil
+}
+
+// DelistService deletes the endpoint with the UUID of the request, which must
+// be owned by the pod of the request.
+func (s *pod2nsmServer) DelistService(ctx context.Context, req *pod2nsm.DelistServiceRequest) (*pod2nsm.DelistServiceResponse, error) {
+	if req.PodNamespace == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the pod are required")
+	}
+	if err := s.plugin.CRD.DelistEndpoint(req.PodNamespace, req.PodName, req.EndpointId); err != nil {
+		return nil, apiError("delisting endpoint "+req.EndpointId, err)
+	}
+	return &pod2nsm.DelistServiceResponse{}, nil
+}
+
+// Heartbeat renews the heartbeat of the endpoint with the UUID of the request,
+// which must be owned by the pod of the request.
+func (s *pod2nsmServer) Heartbeat(ctx context.Context, req *pod2nsm.HeartbeatRequest) (*pod2nsm.HeartbeatResponse, error) {
+	if req.PodNamespace == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the pod are required")
+	}
+	if err := s.plugin.CRD.RenewEndpoint(req.PodNamespace, req.PodName, req.EndpointId); err != nil {
+		return nil, apiError("renewing heartbeat of endpoint "+req.EndpointId, err)
+	}
+	return &pod2nsm.HeartbeatResponse{
+		GracePeriodSeconds: int64(s.plugin.CRD.HeartbeatGracePeriod() / time.Second),
+	}, nil
+}
+
+// ExposeChannel is not supported yet.
+func (s *pod2nsmServer) ExposeChannel(ctx context.Context, req *pod2nsm.ExposeChannelRequest) (*pod2nsm.ExposeChannelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "exposing channels is not supported yet")
+}
+
+// ConcealChannel is not supported yet.
+func (s *pod2nsmServer) ConcealChannel(ctx context.Context, req *pod2nsm.ConcealChannelRequest) (*pod2nsm.ConcealChannelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "concealing channels is not supported yet")
+}
+
+// CreateConnection connects the pod of the request to an endpoint of the
+// service of the request, and returns the UUID of the connection along with
+// the endpoint and the mechanisms selected.
+func (s *pod2nsmServer) CreateConnection(ctx context.Context, req *pod2nsm.CreateConnectionRequest) (*pod2nsm.CreateConnectionResponse, error) {
+	if req.PodNamespace == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the pod are required")
+	}
+	payload, err := netmesh.ParsePayload(req.Payload)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	connReq := &netmeshplugincrd.ConnectionRequest{
+		Namespace:        req.PodNamespace,
+		PodName:          req.PodName,
+		ServiceUUID:      req.ServiceId,
+		Channel:          req.Channel,
+		Payload:          payload,
+		Mechanisms:       req.Mechanisms,
+		RemoteMechanisms: req.RemoteMechanisms,
+		Labels:           req.Labels,
+	}
+	conn, err := s.plugin.CRD.Connect(connReq)
+	if err != nil {
+		return nil, apiError("connecting pod "+req.PodNamespace+"/"+req.PodName+" to service "+req.ServiceId, err)
+	}
+
+	response := &pod2nsm.CreateConnectionResponse{
+		ConnectionId:    conn.Spec.Uuid,
+		EndpointId:      conn.Spec.Endpoint.Uuid,
+		Channel:         conn.Spec.Channel,
+		Mechanism:       conn.Spec.Mechanism,
+		RemoteMechanism: conn.Spec.RemoteMechanism,
+	}
+	if nse, err := s.plugin.CRD.GetNetworkServiceEndpointByUUID(conn.Spec.Endpoint.Uuid); err == nil {
+		response.Socket = nse.Spec.Socket
+	}
+	return response, nil
+}
+
+// DestroyConnection deletes the connection with the UUID of the request, which
+// must be owned by the pod of the request.
+func (s *pod2nsmServer) DestroyConnection(ctx context.Context, req *pod2nsm.DestroyConnectionRequest) (*pod2nsm.DestroyConnectionResponse, error) {
+	if req.PodNamespace == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the pod are required")
+	}
+	if err := s.plugin.CRD.Disconnect(req.PodNamespace, req.PodName, req.ConnectionId); err != nil {
+		return nil, apiError("destroying connection "+req.ConnectionId, err)
+	}
+	return &pod2nsm.DestroyConnectionResponse{}, nil
+}
+
+// endpointResponse describes an endpoint in the pod2nsm API.
+func endpointResponse(nse *v1.NetworkServiceEndpoint) *pod2nsm.GetEndpointResponse {
+	response := &pod2nsm.GetEndpointResponse{
+		EndpointId:       nse.Spec.Uuid,
+		Name:             nse.Name,
+		Namespace:        nse.Namespace,
+		Labels:           nse.Labels,
+		Node:             nse.Spec.Node,
+		Socket:           nse.Spec.Socket,
+		AdvertisedLabels: nse.Spec.Labels,
+		Mechanisms:       nse.Spec.Mechanisms,
+		Capacity:         nse.Spec.Capacity,
+		RemoteMechanisms: nse.Spec.RemoteMechanisms,
+	}
+	if nse.Spec.Pod != nil {
+		response.PodName = nse.Spec.Pod.Name
+	}
+	return response
+}
+
+// lookupError converts the error of a cache lookup by UUID to a gRPC error.
+func lookupError(kind, id string, err error) error {
+	if apierrors.IsNotFound(err) {
+		return status.Errorf(codes.NotFound, "%s %s not found", kind, id)
+	}
+	return status.Errorf(codes.Internal, "error looking up %s %s: %s", kind, id, err)
+}
+
+// apiError converts the error of a write to the API server to a gRPC error.
+func apiError(action string, err error) error {
+	code := codes.Internal
+	switch {
+	case apierrors.IsNotFound(err):
+		code = codes.NotFound
+	case apierrors.IsAlreadyExists(err):
+		code = codes.AlreadyExists
+	case apierrors.IsConflict(err):
+		code = codes.FailedPrecondition
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		code = codes.InvalidArgument
+	case apierrors.IsForbidden(err):
+		code = codes.PermissionDenied
+	case apierrors.IsServiceUnavailable(err):
+		code = codes.Unavailable
+	}
+	return status.Errorf(code, "error %s: %s", action, err)
+}
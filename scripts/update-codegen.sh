@@ -12,10 +12,21 @@ CODEGEN_PKG=${CODEGEN_PKG:-$(cd ${SCRIPT_ROOT}; ls -d -1 ./vendor/k8s.io/code-ge
 echo "Calling ${CODEGEN_PKG}/generate-groups.sh"
 ${CODEGEN_PKG}/generate-groups.sh all \
   github.com/ligato/networkservicemesh/pkg/client github.com/ligato/networkservicemesh/pkg/apis \
-  networkservicemesh.io:v1 \
+  networkservicemesh.io:v1,v2 \
   --output-base "${GOPATH}/src/" \
   --go-header-file ${SCRIPT_ROOT}/conf/boilerplate.txt
 
+# client-gen points the deprecated group accessor Networkservice() at the
+# highest version, keep it on v1 as long as v1 is the storage version
+echo "Pinning the Networkservice() accessor to v1"
+CLIENTSET=${SCRIPT_ROOT}/pkg/client/clientset/versioned
+for file in ${CLIENTSET}/clientset.go ${CLIENTSET}/fake/clientset_generated.go; do
+  sed -i \
+    -e '/^\tNetworkservice() /s/networkservicev2\.NetworkserviceV2/networkservicev1.NetworkserviceV1/' \
+    -e '/^\/\/ \(Deprecated: \)\?Networkservice retrieves/,/^}/{s/networkservicev2/networkservicev1/g;s/NetworkserviceV2/NetworkserviceV1/g;s/networkserviceV2/networkserviceV1/g}' \
+    ${file}
+done
+
 echo "Generating other deepcopy funcs"
 ${GOPATH}/bin/deepcopy-gen \
   --input-dirs ./netmesh/model/netmesh \